@@ -0,0 +1,61 @@
+package clock
+
+import "time"
+
+// Clock is a source of time and timers that can be replaced in tests.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a single event timer created by a Clock.
+type Timer interface {
+	// C returns the channel on which the fire time is delivered. It is nil for timers created by AfterFunc.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing. It returns false if the timer has already fired or been stopped.
+	Stop() bool
+	// Reset changes the timer to fire after d. It returns true if the timer had been active.
+	Reset(d time.Duration) bool
+}
+
+// Real is the Clock backed by the time package.
+var Real Clock = realClock{}
+
+// realClock implements Clock with the time package.
+type realClock struct{}
+
+// Now returns the current local time.
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// NewTimer creates a timer that sends the current time on its channel after d.
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+// AfterFunc calls f in its own goroutine after d.
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return realTimer{time.AfterFunc(d, f)}
+}
+
+// realTimer adapts a *time.Timer to the Timer interface.
+type realTimer struct {
+	t *time.Timer
+}
+
+// C returns the channel of the underlying timer.
+func (r realTimer) C() <-chan time.Time {
+	return r.t.C
+}
+
+// Stop stops the underlying timer.
+func (r realTimer) Stop() bool {
+	return r.t.Stop()
+}
+
+// Reset resets the underlying timer.
+func (r realTimer) Reset(d time.Duration) bool {
+	return r.t.Reset(d)
+}
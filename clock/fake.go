@@ -0,0 +1,134 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Fake is a manually advanced Clock for tests. Timers fire only when Advance or Set moves time past their deadline.
+type Fake struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer // Active timers
+}
+
+// NewFake creates a Fake clock starting at start.
+func NewFake(start time.Time) *Fake {
+	return &Fake{now: start}
+}
+
+// Now returns the current fake time.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// NewTimer creates a timer that sends the fake time on its channel once time reaches now+d.
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	t := &fakeTimer{clock: f, ch: make(chan time.Time, 1)}
+	t.Reset(d)
+	return t
+}
+
+// AfterFunc creates a timer that calls fn once time reaches now+d. fn runs synchronously within Advance or Set.
+func (f *Fake) AfterFunc(d time.Duration, fn func()) Timer {
+	t := &fakeTimer{clock: f, fn: fn}
+	t.Reset(d)
+	return t
+}
+
+// Advance moves time forward by d and fires every timer that becomes due, in deadline order.
+func (f *Fake) Advance(d time.Duration) {
+	f.Set(f.Now().Add(d))
+}
+
+// Set moves time to t and fires every timer that becomes due, in deadline order. Time never moves backwards.
+// Each timer fires with the clock set to its deadline, so timers armed by callbacks also fire if they fall before t.
+func (f *Fake) Set(t time.Time) {
+	for {
+		f.mu.Lock()
+		due := f.nextDue(t)
+		if due == nil {
+			if t.After(f.now) {
+				f.now = t
+			}
+			f.mu.Unlock()
+			return
+		}
+		f.remove(due)
+		if due.when.After(f.now) {
+			f.now = due.when
+		}
+		now := f.now
+		f.mu.Unlock()
+
+		// Fire outside the lock so callbacks may use the clock.
+		if due.fn != nil {
+			due.fn()
+		} else {
+			select {
+			case due.ch <- now:
+			default:
+			}
+		}
+	}
+}
+
+// Timers returns the number of active timers, which lets tests wait until code under test has armed one.
+func (f *Fake) Timers() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.timers)
+}
+
+// nextDue returns the earliest active timer whose deadline is not after t, or nil.
+func (f *Fake) nextDue(t time.Time) *fakeTimer {
+	sort.SliceStable(f.timers, func(i, j int) bool { return f.timers[i].when.Before(f.timers[j].when) })
+	if len(f.timers) > 0 && !f.timers[0].when.After(t) {
+		return f.timers[0]
+	}
+	return nil
+}
+
+// remove deactivates t and reports whether it was active.
+func (f *Fake) remove(t *fakeTimer) bool {
+	for i, other := range f.timers {
+		if other == t {
+			f.timers = append(f.timers[:i], f.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// fakeTimer is a Timer driven by a Fake clock.
+type fakeTimer struct {
+	clock *Fake
+	when  time.Time
+	fn    func()
+	ch    chan time.Time
+}
+
+// C returns the timer channel, or nil for AfterFunc timers.
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+// Stop deactivates the timer.
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	return t.clock.remove(t)
+}
+
+// Reset re-arms the timer to fire d after the current fake time.
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	active := t.clock.remove(t)
+	t.when = t.clock.now.Add(d)
+	t.clock.timers = append(t.clock.timers, t)
+	return active
+}
@@ -0,0 +1,87 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeNowAndAdvance(t *testing.T) {
+	start := time.Unix(1000, 0)
+	c := NewFake(start)
+
+	assert.Equal(t, start, c.Now())
+	c.Advance(time.Second)
+	assert.Equal(t, start.Add(time.Second), c.Now(), "Advance should move time forward")
+
+	c.Set(start)
+	assert.Equal(t, start.Add(time.Second), c.Now(), "Set should never move time backwards")
+}
+
+func TestFakeAfterFuncOrder(t *testing.T) {
+	c := NewFake(time.Unix(0, 0))
+	var fired []int
+	c.AfterFunc(2*time.Second, func() { fired = append(fired, 2) })
+	c.AfterFunc(time.Second, func() { fired = append(fired, 1) })
+	c.AfterFunc(5*time.Second, func() { fired = append(fired, 5) })
+
+	assert.Equal(t, 3, c.Timers())
+	c.Advance(3 * time.Second)
+	assert.Equal(t, []int{1, 2}, fired, "Due timers should fire in deadline order")
+	assert.Equal(t, 1, c.Timers(), "Fired timers should be removed")
+}
+
+func TestFakeTimerChannel(t *testing.T) {
+	c := NewFake(time.Unix(0, 0))
+	timer := c.NewTimer(time.Second)
+
+	c.Advance(500 * time.Millisecond)
+	select {
+	case <-timer.C():
+		t.Fatal("Timer should not fire early")
+	default:
+	}
+
+	c.Advance(500 * time.Millisecond)
+	assert.Equal(t, time.Unix(1, 0), <-timer.C(), "Timer should deliver the fire time")
+}
+
+func TestFakeTimerStopAndReset(t *testing.T) {
+	c := NewFake(time.Unix(0, 0))
+	fired := 0
+	timer := c.AfterFunc(time.Second, func() { fired++ })
+
+	assert.True(t, timer.Stop(), "Stop should report an active timer")
+	assert.False(t, timer.Stop(), "Stop should report an inactive timer")
+	c.Advance(2 * time.Second)
+	assert.Equal(t, 0, fired, "Stopped timer should not fire")
+
+	assert.False(t, timer.Reset(time.Second), "Reset should report that the timer was inactive")
+	c.Advance(time.Second)
+	assert.Equal(t, 1, fired, "Reset timer should fire")
+}
+
+func TestFakeCallbackArmsTimer(t *testing.T) {
+	c := NewFake(time.Unix(0, 0))
+	fired := 0
+	var tick func()
+	tick = func() {
+		fired++
+		c.AfterFunc(time.Second, tick)
+	}
+	c.AfterFunc(time.Second, tick)
+
+	c.Advance(3 * time.Second)
+	assert.Equal(t, 3, fired, "Timers armed by callbacks should fire within the same Advance")
+}
+
+func TestRealClock(t *testing.T) {
+	timer := Real.NewTimer(time.Millisecond)
+	<-timer.C()
+
+	done := make(chan struct{})
+	Real.AfterFunc(time.Millisecond, func() { close(done) })
+	<-done
+	assert.WithinDuration(t, time.Now(), Real.Now(), time.Second)
+}
@@ -0,0 +1,170 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrBrokenBarrier is returned to parties waiting on a CyclicBarrier that was broken by a cancelled waiter,
+// a failing barrier action or a Reset.
+var ErrBrokenBarrier = errors.New("concurrency: barrier is broken")
+
+// barrierGeneration is one use of a CyclicBarrier.
+type barrierGeneration struct {
+	done   chan struct{} // Closed when the generation trips or breaks
+	broken bool
+}
+
+// CyclicBarrier lets a fixed number of parties wait for each other, then releases them all together.
+// It can be reused once released, and optionally runs an action each time it trips.
+type CyclicBarrier struct {
+	mu      sync.Mutex
+	parties int
+	action  func() error
+	count   int // Parties waiting in the current generation
+	gen     *barrierGeneration
+}
+
+// NewCyclicBarrier creates a barrier for parties parties. If action is non-nil it is run by the last arriving
+// party, before the others are released; an error or panic from it breaks the barrier.
+// The action must not call methods of the barrier. It panics if parties is not positive, since such a barrier
+// could never trip.
+func NewCyclicBarrier(parties int, action func() error) *CyclicBarrier {
+	if parties <= 0 {
+		panic(fmt.Sprintf("concurrency: cyclic barrier needs at least one party, got %d", parties))
+	}
+	return &CyclicBarrier{
+		parties: parties,
+		action:  action,
+		gen:     &barrierGeneration{done: make(chan struct{})},
+	}
+}
+
+// Await waits until all parties have called Await, then returns the arrival index of the caller:
+// parties-1 for the first to arrive and 0 for the last. If ctx is done first, the barrier breaks,
+// the caller gets ctx.Err() and every other waiter gets ErrBrokenBarrier.
+func (b *CyclicBarrier) Await(ctx context.Context) (int, error) {
+	b.mu.Lock()
+	gen := b.gen
+	if gen.broken {
+		b.mu.Unlock()
+		return 0, ErrBrokenBarrier
+	}
+	if err := ctx.Err(); err != nil {
+		b.breakLocked()
+		b.mu.Unlock()
+		return 0, err
+	}
+
+	b.count++
+	index := b.parties - b.count
+	if index == 0 {
+		err := b.runAction()
+		if err != nil {
+			b.breakLocked()
+		} else {
+			b.nextGenerationLocked()
+		}
+		b.mu.Unlock()
+		return 0, err
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-gen.done:
+	case <-ctx.Done():
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		// A generation that is still open is the current one, and the cancellation breaks it.
+		if !isClosed(gen.done) {
+			b.breakLocked()
+			return 0, ctx.Err()
+		}
+		if gen.broken {
+			return 0, ErrBrokenBarrier
+		}
+		return index, nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if gen.broken {
+		return 0, ErrBrokenBarrier
+	}
+	return index, nil
+}
+
+// Reset breaks the current generation, releasing its waiters with ErrBrokenBarrier, and starts a new one.
+func (b *CyclicBarrier) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.breakLocked()
+	b.nextGenerationLocked()
+}
+
+// IsBroken reports whether the current generation is broken.
+func (b *CyclicBarrier) IsBroken() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.gen.broken
+}
+
+// Waiting returns the number of parties currently waiting at the barrier.
+func (b *CyclicBarrier) Waiting() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.count
+}
+
+// Parties returns the number of parties required to trip the barrier.
+func (b *CyclicBarrier) Parties() int {
+	return b.parties
+}
+
+// runAction runs the barrier action, converting a panic into an error.
+func (b *CyclicBarrier) runAction() (err error) {
+	if b.action == nil {
+		return nil
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: action panicked: %v", ErrBrokenBarrier, r)
+		}
+	}()
+	return b.action()
+}
+
+// breakLocked marks the current generation broken and releases its waiters.
+func (b *CyclicBarrier) breakLocked() {
+	if !b.gen.broken {
+		b.gen.broken = true
+		b.count = 0
+		closeIfOpen(b.gen.done)
+	}
+}
+
+// nextGenerationLocked releases the current generation and starts a new one.
+func (b *CyclicBarrier) nextGenerationLocked() {
+	closeIfOpen(b.gen.done)
+	b.count = 0
+	b.gen = &barrierGeneration{done: make(chan struct{})}
+}
+
+// isClosed reports whether ch has been closed.
+func isClosed(ch chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+// closeIfOpen closes ch unless it is already closed. Callers must serialize calls for the same channel.
+func closeIfOpen(ch chan struct{}) {
+	if !isClosed(ch) {
+		close(ch)
+	}
+}
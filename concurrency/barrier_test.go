@@ -0,0 +1,108 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCyclicBarrierReleasesAllParties(t *testing.T) {
+	var actions int32
+	b := NewCyclicBarrier(3, func() error {
+		atomic.AddInt32(&actions, 1)
+		return nil
+	})
+
+	for round := 0; round < 2; round++ {
+		indexes := make([]int, 3)
+		var wg sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				idx, err := b.Await(context.Background())
+				assert.NoError(t, err)
+				indexes[i] = idx
+			}()
+		}
+		wg.Wait()
+
+		sort.Ints(indexes)
+		assert.Equal(t, []int{0, 1, 2}, indexes, "Each party should get a distinct arrival index")
+	}
+	assert.Equal(t, int32(2), actions, "Barrier action should run once per trip")
+	assert.Equal(t, 0, b.Waiting())
+}
+
+func TestCyclicBarrierTimeoutBreaks(t *testing.T) {
+	b := NewCyclicBarrier(3, nil)
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := b.Await(context.Background())
+		errs <- err
+	}()
+	assert.Eventually(t, func() bool { return b.Waiting() == 1 }, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := b.Await(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "The timed-out party should get its context error")
+	assert.ErrorIs(t, <-errs, ErrBrokenBarrier, "Other parties should get ErrBrokenBarrier")
+	assert.True(t, b.IsBroken())
+
+	_, err = b.Await(context.Background())
+	assert.ErrorIs(t, err, ErrBrokenBarrier, "A broken barrier should reject new arrivals")
+
+	b.Reset()
+	assert.False(t, b.IsBroken(), "Reset should repair the barrier")
+}
+
+func TestCyclicBarrierResetReleasesWaiters(t *testing.T) {
+	b := NewCyclicBarrier(2, nil)
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := b.Await(context.Background())
+		errs <- err
+	}()
+	assert.Eventually(t, func() bool { return b.Waiting() == 1 }, time.Second, time.Millisecond)
+
+	b.Reset()
+	assert.ErrorIs(t, <-errs, ErrBrokenBarrier, "Reset should release waiters with ErrBrokenBarrier")
+}
+
+func TestCyclicBarrierActionError(t *testing.T) {
+	errAction := errors.New("action failed")
+	b := NewCyclicBarrier(2, func() error { return errAction })
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := b.Await(context.Background())
+		errs <- err
+	}()
+	assert.Eventually(t, func() bool { return b.Waiting() == 1 }, time.Second, time.Millisecond)
+
+	_, err := b.Await(context.Background())
+	assert.ErrorIs(t, err, errAction, "The party running the action should get its error")
+	assert.ErrorIs(t, <-errs, ErrBrokenBarrier, "A failing action should break the barrier")
+}
+
+func TestCyclicBarrierActionPanic(t *testing.T) {
+	b := NewCyclicBarrier(1, func() error { panic("boom") })
+
+	_, err := b.Await(context.Background())
+	assert.ErrorIs(t, err, ErrBrokenBarrier, "A panicking action should break the barrier")
+}
+
+func TestCyclicBarrierRejectsNonPositiveParties(t *testing.T) {
+	assert.PanicsWithValue(t, "concurrency: cyclic barrier needs at least one party, got 0", func() { NewCyclicBarrier(0, nil) },
+		"A barrier without parties could never trip")
+	assert.Panics(t, func() { NewCyclicBarrier(-1, nil) }, "A negative party count should be rejected")
+}
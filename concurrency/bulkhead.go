@@ -0,0 +1,202 @@
+package concurrency
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrBulkheadFull is returned when a partition's waiting queue is full or its maximum queue wait elapses.
+	ErrBulkheadFull = errors.New("concurrency: bulkhead partition is full")
+	// ErrUnknownPartition is returned for a partition name the bulkhead was not configured with.
+	ErrUnknownPartition = errors.New("concurrency: unknown bulkhead partition")
+)
+
+// BulkheadPartition configures one isolated partition of a Bulkhead.
+type BulkheadPartition struct {
+	Capacity int           // Slots reserved for the partition
+	MaxQueue int           // Maximum number of waiting callers; 0 means unbounded
+	MaxWait  time.Duration // Maximum time a caller waits for a slot; 0 means until its context is done
+}
+
+// BulkheadMetrics is a snapshot of a partition's activity.
+type BulkheadMetrics struct {
+	InUse    int    // Slots held, including borrowed ones
+	Borrowed int    // Slots held from the shared pool
+	Waiting  int    // Callers waiting for a slot
+	Admitted uint64 // Callers that obtained a slot
+	Rejected uint64 // Callers turned away because the queue was full
+	TimedOut uint64 // Callers that gave up after MaxWait
+}
+
+// Bulkhead isolates named partitions, such as tenants or downstream dependencies, by giving each its own
+// slots and waiting queue. Partitions may borrow unused slots from a shared pool, so one busy partition
+// cannot exhaust capacity reserved for the others. Within a partition, callers obtain slots in arrival order.
+type Bulkhead struct {
+	shared     *Semaphore // Nil when there is no shared pool
+	partitions map[string]*bulkheadPartition
+}
+
+// bulkheadPartition is the runtime state of a partition.
+type bulkheadPartition struct {
+	config BulkheadPartition
+	slots  *Semaphore
+
+	mu      sync.Mutex
+	metrics BulkheadMetrics
+	queue   *list.List // Waiting callers as *bulkheadWaiter, oldest first
+}
+
+// bulkheadWaiter is a caller queued in a partition.
+type bulkheadWaiter struct {
+	turn chan struct{} // Closed when the waiter reaches the front of the queue
+}
+
+// NewBulkhead creates a Bulkhead with a shared pool of sharedCapacity slots and the given partitions.
+func NewBulkhead(sharedCapacity int, partitions map[string]BulkheadPartition) *Bulkhead {
+	b := &Bulkhead{partitions: make(map[string]*bulkheadPartition, len(partitions))}
+	if sharedCapacity > 0 {
+		b.shared = NewSemaphore(sharedCapacity)
+	}
+	for name, config := range partitions {
+		b.partitions[name] = &bulkheadPartition{config: config, slots: NewSemaphore(config.Capacity), queue: list.New()}
+	}
+	return b
+}
+
+// Acquire obtains a slot in partition, preferring the partition's own slots over the shared pool.
+// The returned release function must be called exactly once when the work is done.
+func (b *Bulkhead) Acquire(ctx context.Context, partition string) (release func(), err error) {
+	p, ok := b.partitions[partition]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPartition, partition)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return b.wait(ctx, p)
+}
+
+// Execute runs fn while holding a slot in partition.
+func (b *Bulkhead) Execute(ctx context.Context, partition string, fn func() error) error {
+	release, err := b.Acquire(ctx, partition)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return fn()
+}
+
+// Metrics returns a snapshot of the metrics of partition.
+func (b *Bulkhead) Metrics(partition string) (BulkheadMetrics, bool) {
+	p, ok := b.partitions[partition]
+	if !ok {
+		return BulkheadMetrics{}, false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.metrics, true
+}
+
+// wait takes a free slot if nobody is queued ahead of the caller. Otherwise it queues the caller until it
+// reaches the front and a partition or shared slot frees up, the queue wait elapses or ctx is done.
+func (b *Bulkhead) wait(ctx context.Context, p *bulkheadPartition) (func(), error) {
+	p.mu.Lock()
+	if p.queue.Len() == 0 {
+		// Free slots may only be taken directly when no earlier caller is waiting for them.
+		if p.slots.TryAcquire() {
+			p.mu.Unlock()
+			return p.admitted(p.slots, false), nil
+		}
+		if b.shared != nil && b.shared.TryAcquire() {
+			p.mu.Unlock()
+			return p.admitted(b.shared, true), nil
+		}
+	}
+	if p.config.MaxQueue > 0 && p.metrics.Waiting >= p.config.MaxQueue {
+		p.metrics.Rejected++
+		p.mu.Unlock()
+		return nil, ErrBulkheadFull
+	}
+	w := &bulkheadWaiter{turn: make(chan struct{})}
+	elem := p.queue.PushBack(w)
+	if p.queue.Front() == elem {
+		close(w.turn)
+	}
+	p.metrics.Waiting++
+	p.mu.Unlock()
+	defer p.dequeue(elem)
+
+	var timeout <-chan time.Time
+	if p.config.MaxWait > 0 {
+		timer := time.NewTimer(p.config.MaxWait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case <-w.turn:
+	case <-timeout:
+		p.timedOut()
+		return nil, ErrBulkheadFull
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	sem, err := acquireEither(ctx, timeout, p.slots, b.shared)
+	switch {
+	case errors.Is(err, errAcquireTimeout):
+		p.timedOut()
+		return nil, ErrBulkheadFull
+	case err != nil:
+		return nil, err
+	}
+	return p.admitted(sem, sem != p.slots), nil
+}
+
+// dequeue removes a waiter from the queue and, if it was at the front, hands the turn to the next one.
+func (p *bulkheadPartition) dequeue(elem *list.Element) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	wasFront := p.queue.Front() == elem
+	p.queue.Remove(elem)
+	p.metrics.Waiting--
+	if next := p.queue.Front(); wasFront && next != nil {
+		close(next.Value.(*bulkheadWaiter).turn)
+	}
+}
+
+// timedOut counts a caller that gave up after MaxWait.
+func (p *bulkheadPartition) timedOut() {
+	p.mu.Lock()
+	p.metrics.TimedOut++
+	p.mu.Unlock()
+}
+
+// admitted records a slot taken from sem and returns the function that gives it back.
+func (p *bulkheadPartition) admitted(sem *Semaphore, borrowed bool) func() {
+	p.mu.Lock()
+	p.metrics.Admitted++
+	p.metrics.InUse++
+	if borrowed {
+		p.metrics.Borrowed++
+	}
+	p.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			p.mu.Lock()
+			p.metrics.InUse--
+			if borrowed {
+				p.metrics.Borrowed--
+			}
+			p.mu.Unlock()
+			sem.Release()
+		})
+	}
+}
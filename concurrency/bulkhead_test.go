@@ -0,0 +1,206 @@
+package concurrency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkheadPartitionCapacity(t *testing.T) {
+	b := NewBulkhead(0, map[string]BulkheadPartition{
+		"tenant-a": {Capacity: 2, MaxWait: 20 * time.Millisecond},
+		"tenant-b": {Capacity: 1},
+	})
+	ctx := context.Background()
+
+	r1, err := b.Acquire(ctx, "tenant-a")
+	assert.NoError(t, err)
+	r2, err := b.Acquire(ctx, "tenant-a")
+	assert.NoError(t, err)
+
+	_, err = b.Acquire(ctx, "tenant-a")
+	assert.ErrorIs(t, err, ErrBulkheadFull, "A full partition should time out after MaxWait")
+
+	r3, err := b.Acquire(ctx, "tenant-b")
+	assert.NoError(t, err, "A full partition should not affect other partitions")
+
+	m, ok := b.Metrics("tenant-a")
+	assert.True(t, ok)
+	assert.Equal(t, BulkheadMetrics{InUse: 2, Admitted: 2, TimedOut: 1}, m)
+
+	r1()
+	r1() // Releasing twice is harmless
+	r2()
+	r3()
+	m, _ = b.Metrics("tenant-a")
+	assert.Equal(t, 0, m.InUse, "Released slots should be returned")
+}
+
+func TestBulkheadBorrowing(t *testing.T) {
+	b := NewBulkhead(1, map[string]BulkheadPartition{
+		"a": {Capacity: 1, MaxWait: 10 * time.Millisecond},
+		"b": {Capacity: 1, MaxWait: 10 * time.Millisecond},
+	})
+	ctx := context.Background()
+
+	ra, _ := b.Acquire(ctx, "a")
+	borrowed, err := b.Acquire(ctx, "a")
+	assert.NoError(t, err, "A full partition should borrow from the shared pool")
+	m, _ := b.Metrics("a")
+	assert.Equal(t, 1, m.Borrowed)
+
+	_, err = b.Acquire(ctx, "a")
+	assert.ErrorIs(t, err, ErrBulkheadFull, "Borrowing should stop when the shared pool is empty")
+
+	rb, err := b.Acquire(ctx, "b")
+	assert.NoError(t, err, "Borrowing should not consume another partition's own slots")
+
+	borrowed()
+	m, _ = b.Metrics("a")
+	assert.Equal(t, 0, m.Borrowed)
+	ra()
+	rb()
+}
+
+func TestBulkheadWaitsForRelease(t *testing.T) {
+	b := NewBulkhead(0, map[string]BulkheadPartition{"a": {Capacity: 1}})
+	ctx := context.Background()
+
+	release, _ := b.Acquire(ctx, "a")
+	acquired := make(chan struct{})
+	go func() {
+		r, err := b.Acquire(ctx, "a")
+		assert.NoError(t, err)
+		close(acquired)
+		r()
+	}()
+
+	assert.Eventually(t, func() bool {
+		m, _ := b.Metrics("a")
+		return m.Waiting == 1
+	}, time.Second, time.Millisecond, "Caller should be queued")
+	release()
+
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("Queued caller was not admitted after release")
+	}
+}
+
+func TestBulkheadMaxQueue(t *testing.T) {
+	b := NewBulkhead(0, map[string]BulkheadPartition{"a": {Capacity: 1, MaxQueue: 1}})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	release, _ := b.Acquire(ctx, "a")
+	defer release()
+	go func() { _, _ = b.Acquire(ctx, "a") }()
+	assert.Eventually(t, func() bool {
+		m, _ := b.Metrics("a")
+		return m.Waiting == 1
+	}, time.Second, time.Millisecond)
+
+	_, err := b.Acquire(ctx, "a")
+	assert.ErrorIs(t, err, ErrBulkheadFull, "Callers beyond MaxQueue should be rejected immediately")
+	m, _ := b.Metrics("a")
+	assert.Equal(t, uint64(1), m.Rejected)
+}
+
+func TestBulkheadContextAndUnknownPartition(t *testing.T) {
+	b := NewBulkhead(0, map[string]BulkheadPartition{"a": {Capacity: 1}})
+
+	_, err := b.Acquire(context.Background(), "missing")
+	assert.ErrorIs(t, err, ErrUnknownPartition)
+	_, ok := b.Metrics("missing")
+	assert.False(t, ok)
+
+	release, _ := b.Acquire(context.Background(), "a")
+	defer release()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = b.Acquire(ctx, "a")
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Waiting should stop when the context expires")
+}
+
+func TestBulkheadExecute(t *testing.T) {
+	b := NewBulkhead(0, map[string]BulkheadPartition{"a": {Capacity: 1}})
+
+	ran := false
+	err := b.Execute(context.Background(), "a", func() error {
+		ran = true
+		m, _ := b.Metrics("a")
+		assert.Equal(t, 1, m.InUse, "Slot should be held while fn runs")
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, ran)
+	m, _ := b.Metrics("a")
+	assert.Equal(t, 0, m.InUse, "Slot should be released after fn returns")
+}
+
+func TestBulkheadWaitersAreFIFO(t *testing.T) {
+	b := NewBulkhead(0, map[string]BulkheadPartition{"tenant-a": {Capacity: 1}})
+	ctx := context.Background()
+
+	release, err := b.Acquire(ctx, "tenant-a")
+	assert.NoError(t, err)
+
+	const waiters = 5
+	order := make(chan int, waiters)
+	for i := 0; i < waiters; i++ {
+		go func(i int) {
+			r, err := b.Acquire(ctx, "tenant-a")
+			if err != nil {
+				return
+			}
+			order <- i
+			r()
+		}(i)
+		// Let each waiter queue before starting the next.
+		for {
+			m, _ := b.Metrics("tenant-a")
+			if m.Waiting == i+1 {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	release()
+	for want := 0; want < waiters; want++ {
+		select {
+		case got := <-order:
+			assert.Equal(t, want, got, "Waiters should obtain slots in arrival order")
+		case <-time.After(time.Second):
+			t.Fatal("Waiters should be admitted once slots free up")
+		}
+	}
+}
+
+func TestBulkheadNewCallerDoesNotJumpQueue(t *testing.T) {
+	b := NewBulkhead(0, map[string]BulkheadPartition{"tenant-a": {Capacity: 1}})
+	ctx := context.Background()
+
+	release, _ := b.Acquire(ctx, "tenant-a")
+	admitted := make(chan func())
+	go func() {
+		r, _ := b.Acquire(ctx, "tenant-a")
+		admitted <- r
+	}()
+	for {
+		if m, _ := b.Metrics("tenant-a"); m.Waiting == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	release()
+	timeout, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err := b.Acquire(timeout, "tenant-a")
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "A new caller should not take a slot ahead of a queued one")
+	(<-admitted)()
+}
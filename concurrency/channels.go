@@ -0,0 +1,232 @@
+package concurrency
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/vd09/go-generic-utils/queue"
+)
+
+// The helpers in this file start goroutines that forward values between channels. Each output channel is
+// closed once its inputs are closed and drained or ctx is done, so no goroutine outlives its context.
+
+// send delivers v on out unless ctx is done first. It reports whether v was sent.
+func send[T any](ctx context.Context, out chan<- T, v T) bool {
+	select {
+	case out <- v:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// receive reads the next value from in unless ctx is done first. ok is false when in is closed or ctx is done.
+func receive[T any](ctx context.Context, in <-chan T) (v T, ok bool) {
+	select {
+	case v, ok = <-in:
+		return v, ok
+	case <-ctx.Done():
+		return v, false
+	}
+}
+
+// OrDone returns a channel that yields the values of in until in is closed or ctx is done.
+func OrDone[T any](ctx context.Context, in <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for {
+			v, ok := receive(ctx, in)
+			if !ok || !send(ctx, out, v) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Merge returns a channel that yields the values of all inputs, in no particular order.
+func Merge[T any](ctx context.Context, ins ...<-chan T) <-chan T {
+	out := make(chan T)
+	var wg sync.WaitGroup
+	wg.Add(len(ins))
+	for _, in := range ins {
+		go func() {
+			defer wg.Done()
+			for {
+				v, ok := receive(ctx, in)
+				if !ok || !send(ctx, out, v) {
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// Tee returns n channels that each yield every value of in. A value is delivered to all outputs
+// before the next one is read, so the slowest consumer sets the pace.
+func Tee[T any](ctx context.Context, in <-chan T, n int) []<-chan T {
+	outs := make([]chan T, n)
+	result := make([]<-chan T, n)
+	for i := range outs {
+		outs[i] = make(chan T)
+		result[i] = outs[i]
+	}
+
+	go func() {
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+		for {
+			v, ok := receive(ctx, in)
+			if !ok {
+				return
+			}
+			for _, out := range outs {
+				if !send(ctx, out, v) {
+					return
+				}
+			}
+		}
+	}()
+	return result
+}
+
+// Partition splits in into values for which pred returns true and values for which it returns false.
+// Both outputs must be consumed, since a value waits until the output it belongs to accepts it.
+func Partition[T any](ctx context.Context, in <-chan T, pred func(T) bool) (matched, unmatched <-chan T) {
+	outs := PartitionN(ctx, in, 2, func(v T) int {
+		if pred(v) {
+			return 0
+		}
+		return 1
+	})
+	return outs[0], outs[1]
+}
+
+// PartitionN splits in into n channels, sending each value to the output at index(v) modulo n.
+// Hashing a key into index keeps all values with the same key on the same output.
+func PartitionN[T any](ctx context.Context, in <-chan T, n int, index func(T) int) []<-chan T {
+	outs := make([]chan T, n)
+	result := make([]<-chan T, n)
+	for i := range outs {
+		outs[i] = make(chan T)
+		result[i] = outs[i]
+	}
+
+	go func() {
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+		for {
+			v, ok := receive(ctx, in)
+			if !ok {
+				return
+			}
+			i := index(v) % n
+			if i < 0 {
+				i += n
+			}
+			if !send(ctx, outs[i], v) {
+				return
+			}
+		}
+	}()
+	return result
+}
+
+// Buffer returns a channel that yields the values of in in order, buffering without bound
+// so that the producer never waits for the consumer.
+func Buffer[T any](ctx context.Context, in <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		pending := queue.NewQueue[T]()
+		for in != nil || !pending.IsEmpty() {
+			// A nil channel blocks forever, which disables the corresponding case.
+			var sendCh chan T
+			next, ok := pending.Peek()
+			if ok {
+				sendCh = out
+			}
+			select {
+			case v, open := <-in:
+				if !open {
+					in = nil
+					continue
+				}
+				pending.Enqueue(v)
+			case sendCh <- next:
+				pending.Dequeue()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// BatchChan groups the values of in into slices of up to size values. A partial batch is emitted once
+// maxWait has passed since its first value, or when in is closed. A maxWait of zero only emits full batches
+// and the final partial one.
+func BatchChan[T any](ctx context.Context, in <-chan T, size int, maxWait time.Duration) <-chan []T {
+	out := make(chan []T)
+	go func() {
+		defer close(out)
+		var batch []T
+		var timer *time.Timer
+		var timeout <-chan time.Time
+
+		flush := func() bool {
+			if timer != nil {
+				timer.Stop()
+				timer, timeout = nil, nil
+			}
+			if len(batch) == 0 {
+				return true
+			}
+			full := batch
+			batch = nil
+			return send(ctx, out, full)
+		}
+
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					flush()
+					return
+				}
+				batch = append(batch, v)
+				if len(batch) == 1 && maxWait > 0 {
+					timer = time.NewTimer(maxWait)
+					timeout = timer.C
+				}
+				if len(batch) >= size && !flush() {
+					return
+				}
+			case <-timeout:
+				timer, timeout = nil, nil
+				if !flush() {
+					return
+				}
+			case <-ctx.Done():
+				if timer != nil {
+					timer.Stop()
+				}
+				return
+			}
+		}
+	}()
+	return out
+}
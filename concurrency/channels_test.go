@@ -0,0 +1,191 @@
+package concurrency
+
+import (
+	"context"
+	"runtime"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// generate returns a channel yielding values and then closing.
+func generate[T any](values ...T) <-chan T {
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		for _, v := range values {
+			ch <- v
+		}
+	}()
+	return ch
+}
+
+// collect reads ch until it is closed.
+func collect[T any](ch <-chan T) []T {
+	var values []T
+	for v := range ch {
+		values = append(values, v)
+	}
+	return values
+}
+
+// assertNoLeak fails if the number of goroutines does not return to the baseline.
+func assertNoLeak(t *testing.T, baseline int) {
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > baseline && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	assert.LessOrEqual(t, runtime.NumGoroutine(), baseline, "Goroutines should exit")
+}
+
+func TestOrDone(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 3}, collect(OrDone(context.Background(), generate(1, 2, 3))))
+
+	baseline := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int)
+	out := OrDone(ctx, in)
+	cancel()
+	_, ok := <-out
+	assert.False(t, ok, "OrDone should close its output when the context is cancelled")
+	assertNoLeak(t, baseline)
+}
+
+func TestMerge(t *testing.T) {
+	values := collect(Merge(context.Background(), generate(1, 2), generate(3), generate(4, 5, 6)))
+	sort.Ints(values)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, values, "Merge should yield every input value")
+
+	baseline := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	out := Merge(ctx, make(chan int), make(chan int))
+	cancel()
+	assert.Empty(t, collect(out))
+	assertNoLeak(t, baseline)
+}
+
+func TestTee(t *testing.T) {
+	outs := Tee(context.Background(), generate(1, 2, 3), 3)
+
+	results := make([][]int, len(outs))
+	var wg sync.WaitGroup
+	for i, out := range outs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = collect(out)
+		}()
+	}
+	wg.Wait()
+
+	for _, r := range results {
+		assert.Equal(t, []int{1, 2, 3}, r, "Every Tee output should yield every value")
+	}
+}
+
+func TestTeeCancel(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int, 1)
+	in <- 1
+	outs := Tee(ctx, in, 2)
+	<-outs[0] // The second output is never read
+	cancel()
+	assertNoLeak(t, baseline)
+}
+
+func TestPartition(t *testing.T) {
+	even, odd := Partition(context.Background(), generate(1, 2, 3, 4, 5), func(v int) bool { return v%2 == 0 })
+
+	var evens, odds []int
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); evens = collect(even) }()
+	go func() { defer wg.Done(); odds = collect(odd) }()
+	wg.Wait()
+
+	assert.Equal(t, []int{2, 4}, evens)
+	assert.Equal(t, []int{1, 3, 5}, odds)
+}
+
+func TestPartitionN(t *testing.T) {
+	words := generate("apple", "avocado", "banana", "blueberry", "cherry")
+	outs := PartitionN(context.Background(), words, 3, func(s string) int { return int(s[0]) - 'a' - 3 })
+
+	results := make([][]string, len(outs))
+	var wg sync.WaitGroup
+	for i, out := range outs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = collect(out)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, []string{"apple", "avocado"}, results[0], "Negative indexes should wrap around")
+	assert.Equal(t, []string{"banana", "blueberry"}, results[1], "Values with the same key should share an output")
+	assert.Equal(t, []string{"cherry"}, results[2])
+}
+
+func TestBuffer(t *testing.T) {
+	in := make(chan int)
+	out := Buffer(context.Background(), in)
+
+	for i := 0; i < 100; i++ {
+		in <- i // Never blocks on the consumer
+	}
+	close(in)
+
+	values := collect(out)
+	assert.Equal(t, 100, len(values))
+	for i, v := range values {
+		assert.Equal(t, i, v, "Buffer should preserve order")
+	}
+}
+
+func TestBufferCancel(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int)
+	out := Buffer(ctx, in)
+	in <- 1
+	cancel()
+	collect(out)
+	assertNoLeak(t, baseline)
+}
+
+func TestBatchChanBySize(t *testing.T) {
+	batches := collect(BatchChan(context.Background(), generate(1, 2, 3, 4, 5), 2, 0))
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, batches, "Batches should be split by size and flushed on close")
+}
+
+func TestBatchChanByTime(t *testing.T) {
+	in := make(chan int)
+	out := BatchChan(context.Background(), in, 10, 20*time.Millisecond)
+
+	in <- 1
+	in <- 2
+	start := time.Now()
+	batch := <-out
+	assert.Equal(t, []int{1, 2}, batch, "A partial batch should be emitted after maxWait")
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+
+	in <- 3
+	close(in)
+	assert.Equal(t, [][]int{{3}}, collect(out))
+}
+
+func TestBatchChanCancel(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int)
+	out := BatchChan(ctx, in, 10, time.Hour)
+	in <- 1
+	cancel()
+	assert.Empty(t, collect(out), "Pending batch should be dropped on cancellation")
+	assertNoLeak(t, baseline)
+}
@@ -0,0 +1,275 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/vd09/go-generic-utils/slicehelper"
+)
+
+var (
+	// ErrCircuitOpen is returned by CircuitBreaker.Execute while the circuit is open.
+	ErrCircuitOpen = errors.New("concurrency: circuit breaker is open")
+	// ErrTooManyProbes is returned by CircuitBreaker.Execute when the half-open probe quota is in use.
+	ErrTooManyProbes = errors.New("concurrency: circuit breaker half-open probe quota exceeded")
+)
+
+// CircuitState is the state of a CircuitBreaker.
+type CircuitState int
+
+const (
+	CircuitClosed   CircuitState = iota // Calls pass through and outcomes are recorded
+	CircuitOpen                         // Calls are rejected until the open timeout elapses
+	CircuitHalfOpen                     // A limited number of probe calls decide whether to close again
+)
+
+// String returns the name of the state.
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreakerSettings configures a CircuitBreaker. Zero values select the documented defaults.
+type CircuitBreakerSettings struct {
+	WindowSize           int           // Number of recent outcomes in the rolling window (default 10)
+	MinimumCalls         int           // Outcomes required before the failure rate is evaluated (default WindowSize)
+	FailureRateThreshold float64       // Failure ratio in (0, 1] that opens the circuit (default 0.5 if both checks are 0)
+	ConsecutiveFailures  int           // Consecutive failures that open the circuit; 0 disables the check
+	OpenTimeout          time.Duration // Time spent open before probing (default 60s)
+	HalfOpenMaxCalls     int           // Probes allowed while half-open; all must succeed to close (default 1)
+
+	// IsFailure classifies the error returned by a call. By default every non-nil error is a failure.
+	IsFailure func(err error) bool
+	// OnStateChange is called on every state transition, in order, after the breaker is unlocked,
+	// so it may call back into the breaker.
+	OnStateChange func(from, to CircuitState)
+}
+
+// CircuitBreaker stops calling a failing dependency for a while, then probes it before resuming traffic.
+type CircuitBreaker[T any] struct {
+	mu          sync.Mutex
+	settings    CircuitBreakerSettings
+	state       CircuitState
+	generation  uint64                         // Incremented on every transition so stale outcomes are ignored
+	window      *slicehelper.CyclicSlice[bool] // Rolling window of outcomes; true marks a failure
+	failures    int                            // Failures currently in the window
+	consecutive int                            // Consecutive failures in the closed state
+	openedAt    time.Time
+	probes      int // Probes in flight while half-open
+	successes   int // Successful probes while half-open
+	now         func() time.Time
+
+	pending  []stateChange // Transitions not yet passed to OnStateChange
+	notifyMu sync.Mutex    // Held while delivering pending transitions, so they are delivered in order
+}
+
+// stateChange is a transition waiting to be reported to OnStateChange.
+type stateChange struct {
+	from, to CircuitState
+}
+
+// NewCircuitBreaker creates a closed CircuitBreaker with the given settings.
+func NewCircuitBreaker[T any](settings CircuitBreakerSettings) *CircuitBreaker[T] {
+	if settings.FailureRateThreshold <= 0 && settings.ConsecutiveFailures <= 0 {
+		settings.FailureRateThreshold = 0.5 // With both checks disabled the breaker could never open
+	}
+	if settings.WindowSize <= 0 {
+		settings.WindowSize = 10
+	}
+	if settings.MinimumCalls <= 0 || settings.MinimumCalls > settings.WindowSize {
+		settings.MinimumCalls = settings.WindowSize
+	}
+	if settings.OpenTimeout <= 0 {
+		settings.OpenTimeout = 60 * time.Second
+	}
+	if settings.HalfOpenMaxCalls <= 0 {
+		settings.HalfOpenMaxCalls = 1
+	}
+	if settings.IsFailure == nil {
+		settings.IsFailure = func(err error) bool { return err != nil }
+	}
+
+	return &CircuitBreaker[T]{
+		settings: settings,
+		window:   slicehelper.NewCyclicSlice[bool](settings.WindowSize),
+		now:      time.Now,
+	}
+}
+
+// State returns the current state of the breaker.
+func (cb *CircuitBreaker[T]) State() CircuitState {
+	defer cb.notify()
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.refresh(cb.now())
+	return cb.state
+}
+
+// Execute runs fn if the breaker admits the call and records its outcome.
+// It returns ErrCircuitOpen or ErrTooManyProbes without calling fn when the call is rejected.
+// Calls whose ctx is done by the time fn returns are not counted as failures.
+func (cb *CircuitBreaker[T]) Execute(ctx context.Context, fn func() (T, error)) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+	generation, err := cb.admit()
+	if err != nil {
+		return zero, err
+	}
+
+	completed := false
+	defer func() {
+		// A panicking call counts as a failure.
+		if !completed {
+			cb.record(generation, true, false)
+		}
+	}()
+	result, err := fn()
+	completed = true
+
+	cb.record(generation, cb.settings.IsFailure(err), err != nil && ctx.Err() != nil)
+	return result, err
+}
+
+// admit checks whether a call may proceed and returns the generation it belongs to.
+func (cb *CircuitBreaker[T]) admit() (uint64, error) {
+	defer cb.notify()
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.refresh(cb.now())
+	switch cb.state {
+	case CircuitOpen:
+		return 0, ErrCircuitOpen
+	case CircuitHalfOpen:
+		if cb.probes+cb.successes >= cb.settings.HalfOpenMaxCalls {
+			return 0, ErrTooManyProbes
+		}
+		cb.probes++
+	}
+	return cb.generation, nil
+}
+
+// record updates the breaker with the outcome of a call admitted in generation.
+// Neutral outcomes only release the probe slot they occupied.
+func (cb *CircuitBreaker[T]) record(generation uint64, failed, neutral bool) {
+	defer cb.notify()
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if generation != cb.generation {
+		return
+	}
+	switch cb.state {
+	case CircuitClosed:
+		if !neutral {
+			cb.recordClosed(failed)
+		}
+	case CircuitHalfOpen:
+		cb.probes--
+		switch {
+		case neutral:
+		case failed:
+			cb.transition(CircuitOpen)
+		default:
+			cb.successes++
+			if cb.successes >= cb.settings.HalfOpenMaxCalls {
+				cb.transition(CircuitClosed)
+			}
+		}
+	}
+}
+
+// recordClosed adds an outcome to the rolling window and opens the circuit if a threshold is crossed.
+func (cb *CircuitBreaker[T]) recordClosed(failed bool) {
+	if cb.window.IsFull() && cb.window.GetAt(0) {
+		cb.failures--
+	}
+	cb.window.Add(failed)
+	if failed {
+		cb.failures++
+		cb.consecutive++
+	} else {
+		cb.consecutive = 0
+	}
+
+	s := cb.settings
+	tripped := s.ConsecutiveFailures > 0 && cb.consecutive >= s.ConsecutiveFailures
+	if count := cb.window.GetCurrentSize(); s.FailureRateThreshold > 0 && count >= s.MinimumCalls {
+		tripped = tripped || float64(cb.failures)/float64(count) >= s.FailureRateThreshold
+	}
+	if tripped {
+		cb.transition(CircuitOpen)
+	}
+}
+
+// refresh moves an open breaker to half-open once the open timeout has elapsed.
+func (cb *CircuitBreaker[T]) refresh(now time.Time) {
+	if cb.state == CircuitOpen && now.Sub(cb.openedAt) >= cb.settings.OpenTimeout {
+		cb.transition(CircuitHalfOpen)
+	}
+}
+
+// transition switches to state, resetting the counters of the new state.
+func (cb *CircuitBreaker[T]) transition(state CircuitState) {
+	from := cb.state
+	if from == state {
+		return
+	}
+	cb.state = state
+	cb.generation++
+	cb.probes, cb.successes = 0, 0
+	switch state {
+	case CircuitClosed:
+		cb.window.Reset()
+		cb.failures, cb.consecutive = 0, 0
+	case CircuitOpen:
+		cb.openedAt = cb.now()
+	}
+
+	if cb.settings.OnStateChange != nil {
+		cb.pending = append(cb.pending, stateChange{from: from, to: state})
+	}
+}
+
+// notify delivers pending transitions to OnStateChange. It must be called without cb.mu held.
+// If another goroutine, or a callback further up this stack, is already delivering, that call picks up
+// the new transitions instead.
+func (cb *CircuitBreaker[T]) notify() {
+	for {
+		if !cb.notifyMu.TryLock() {
+			return
+		}
+		for {
+			cb.mu.Lock()
+			if len(cb.pending) == 0 {
+				cb.mu.Unlock()
+				break
+			}
+			change := cb.pending[0]
+			cb.pending = cb.pending[1:]
+			cb.mu.Unlock()
+			cb.settings.OnStateChange(change.from, change.to)
+		}
+		cb.notifyMu.Unlock()
+
+		// A transition queued while we were finishing up would otherwise wait for the next call.
+		cb.mu.Lock()
+		more := len(cb.pending) > 0
+		cb.mu.Unlock()
+		if !more {
+			return
+		}
+	}
+}
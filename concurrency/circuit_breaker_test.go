@@ -0,0 +1,195 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errDependency = errors.New("dependency failed")
+
+func succeed() (int, error) { return 1, nil }
+
+func fail() (int, error) { return 0, errDependency }
+
+func TestCircuitBreakerConsecutiveFailures(t *testing.T) {
+	cb := NewCircuitBreaker[int](CircuitBreakerSettings{ConsecutiveFailures: 3})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := cb.Execute(ctx, fail)
+		assert.ErrorIs(t, err, errDependency)
+	}
+	_, _ = cb.Execute(ctx, succeed)
+	assert.Equal(t, CircuitClosed, cb.State(), "A success should reset the consecutive failure count")
+
+	for i := 0; i < 3; i++ {
+		_, _ = cb.Execute(ctx, fail)
+	}
+	assert.Equal(t, CircuitOpen, cb.State(), "Three consecutive failures should open the circuit")
+
+	called := false
+	_, err := cb.Execute(ctx, func() (int, error) { called = true; return 0, nil })
+	assert.ErrorIs(t, err, ErrCircuitOpen, "Open circuit should reject calls")
+	assert.False(t, called, "Open circuit should not call the function")
+}
+
+func TestCircuitBreakerFailureRate(t *testing.T) {
+	cb := NewCircuitBreaker[int](CircuitBreakerSettings{WindowSize: 4, FailureRateThreshold: 0.5})
+	ctx := context.Background()
+
+	_, _ = cb.Execute(ctx, fail)
+	_, _ = cb.Execute(ctx, fail)
+	_, _ = cb.Execute(ctx, succeed)
+	assert.Equal(t, CircuitClosed, cb.State(), "Failure rate should not be evaluated before MinimumCalls")
+
+	_, _ = cb.Execute(ctx, succeed)
+	assert.Equal(t, CircuitOpen, cb.State(), "A 50% failure rate should open the circuit")
+}
+
+func TestCircuitBreakerRollingWindow(t *testing.T) {
+	cb := NewCircuitBreaker[int](CircuitBreakerSettings{WindowSize: 3, FailureRateThreshold: 0.6})
+	ctx := context.Background()
+
+	_, _ = cb.Execute(ctx, fail)
+	_, _ = cb.Execute(ctx, succeed)
+	_, _ = cb.Execute(ctx, succeed)
+	_, _ = cb.Execute(ctx, fail) // Evicts the first failure
+	assert.Equal(t, CircuitClosed, cb.State(), "Old failures should leave the rolling window")
+
+	_, _ = cb.Execute(ctx, fail)
+	assert.Equal(t, CircuitOpen, cb.State(), "Two of the last three calls failing should open the circuit")
+}
+
+func TestCircuitBreakerHalfOpen(t *testing.T) {
+	var transitions []string
+	now := time.Unix(0, 0)
+	cb := NewCircuitBreaker[int](CircuitBreakerSettings{
+		ConsecutiveFailures: 1,
+		OpenTimeout:         time.Second,
+		HalfOpenMaxCalls:    2,
+		OnStateChange: func(from, to CircuitState) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	})
+	cb.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, _ = cb.Execute(ctx, fail)
+	now = now.Add(time.Second)
+	assert.Equal(t, CircuitHalfOpen, cb.State(), "Circuit should half-open after the open timeout")
+
+	_, err := cb.Execute(ctx, fail)
+	assert.ErrorIs(t, err, errDependency)
+	assert.Equal(t, CircuitOpen, cb.State(), "A failed probe should reopen the circuit")
+
+	now = now.Add(time.Second)
+	_, _ = cb.Execute(ctx, succeed)
+	assert.Equal(t, CircuitHalfOpen, cb.State(), "All probes must succeed before closing")
+	_, _ = cb.Execute(ctx, succeed)
+	assert.Equal(t, CircuitClosed, cb.State(), "Successful probes should close the circuit")
+
+	assert.Equal(t, []string{
+		"closed->open", "open->half-open", "half-open->open",
+		"open->half-open", "half-open->closed",
+	}, transitions, "State change callback should observe every transition")
+}
+
+func TestCircuitBreakerProbeQuota(t *testing.T) {
+	now := time.Unix(0, 0)
+	cb := NewCircuitBreaker[int](CircuitBreakerSettings{ConsecutiveFailures: 1, OpenTimeout: time.Second})
+	cb.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, _ = cb.Execute(ctx, fail)
+	now = now.Add(time.Second)
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	go func() {
+		_, _ = cb.Execute(ctx, func() (int, error) {
+			close(started)
+			<-release
+			return 1, nil
+		})
+	}()
+	<-started
+
+	_, err := cb.Execute(ctx, succeed)
+	assert.ErrorIs(t, err, ErrTooManyProbes, "Calls beyond the probe quota should be rejected")
+	close(release)
+	assert.Eventually(t, func() bool { return cb.State() == CircuitClosed }, time.Second, time.Millisecond)
+}
+
+func TestCircuitBreakerContext(t *testing.T) {
+	cb := NewCircuitBreaker[int](CircuitBreakerSettings{ConsecutiveFailures: 1})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := cb.Execute(ctx, func() (int, error) {
+		cancel()
+		return 0, ctx.Err()
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, CircuitClosed, cb.State(), "Cancelled calls should not count as failures")
+
+	_, err = cb.Execute(ctx, succeed)
+	assert.ErrorIs(t, err, context.Canceled, "Execute should not run with a done context")
+}
+
+func TestCircuitBreakerPanicCountsAsFailure(t *testing.T) {
+	cb := NewCircuitBreaker[int](CircuitBreakerSettings{ConsecutiveFailures: 1})
+
+	assert.Panics(t, func() {
+		_, _ = cb.Execute(context.Background(), func() (int, error) { panic("boom") })
+	})
+	assert.Equal(t, CircuitOpen, cb.State(), "A panicking call should be recorded as a failure")
+}
+
+func TestCircuitBreakerIsFailure(t *testing.T) {
+	errNotFound := errors.New("not found")
+	cb := NewCircuitBreaker[int](CircuitBreakerSettings{
+		ConsecutiveFailures: 1,
+		IsFailure:           func(err error) bool { return err != nil && !errors.Is(err, errNotFound) },
+	})
+
+	_, err := cb.Execute(context.Background(), func() (int, error) { return 0, errNotFound })
+	assert.ErrorIs(t, err, errNotFound)
+	assert.Equal(t, CircuitClosed, cb.State(), "Errors classified as success should not open the circuit")
+}
+
+func TestCircuitBreakerOnStateChangeMayCallBreaker(t *testing.T) {
+	var cb *CircuitBreaker[int]
+	var seen []CircuitState
+	cb = NewCircuitBreaker[int](CircuitBreakerSettings{
+		ConsecutiveFailures: 1,
+		OnStateChange: func(from, to CircuitState) {
+			seen = append(seen, cb.State())
+		},
+	})
+
+	done := make(chan struct{})
+	go func() {
+		_, _ = cb.Execute(context.Background(), fail)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Calling State from OnStateChange should not deadlock")
+	}
+	assert.Equal(t, []CircuitState{CircuitOpen}, seen, "The callback should observe the new state")
+}
+
+func TestCircuitBreakerDefaultThreshold(t *testing.T) {
+	cb := NewCircuitBreaker[int](CircuitBreakerSettings{WindowSize: 4})
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, _ = cb.Execute(ctx, succeed)
+		_, _ = cb.Execute(ctx, fail)
+	}
+	assert.Equal(t, CircuitOpen, cb.State(), "Zero settings should open the circuit at the default failure rate")
+}
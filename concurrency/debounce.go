@@ -0,0 +1,231 @@
+package concurrency
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/vd09/go-generic-utils/clock"
+)
+
+// Edge selects on which edge of a burst of calls a debounced function is invoked.
+type Edge int
+
+const (
+	TrailingEdge Edge = iota // Invoke once the calls have stopped for the wait duration
+	LeadingEdge              // Invoke on the first call of a burst
+	BothEdges                // Invoke on the first call and again after the burst if it had further calls
+)
+
+// DebounceOptions configures Debounce and the channel helpers built on it.
+type DebounceOptions struct {
+	Edge    Edge          // Edge on which to invoke (default TrailingEdge)
+	MaxWait time.Duration // Longest time an invocation may be deferred by continuing calls; 0 means no limit
+	Clock   clock.Clock   // Time source (default clock.Real)
+}
+
+// Debouncer delays invocations of a function until calls to it have stopped for a while.
+// All methods are safe for concurrent use, and invocations of the function never overlap.
+type Debouncer struct {
+	core *debouncer[struct{}]
+}
+
+// Debounce returns a Debouncer that invokes fn according to options once calls stop for wait.
+func Debounce(fn func(), wait time.Duration, options DebounceOptions) *Debouncer {
+	return &Debouncer{core: newDebouncer(func(struct{}) { fn() }, wait, options)}
+}
+
+// Throttle returns a Debouncer that invokes fn on the first call and then at most once per interval
+// while calls keep arriving.
+func Throttle(fn func(), interval time.Duration, c clock.Clock) *Debouncer {
+	return Debounce(fn, interval, DebounceOptions{Edge: BothEdges, MaxWait: interval, Clock: c})
+}
+
+// Call schedules an invocation of the function.
+func (d *Debouncer) Call() {
+	d.core.call(struct{}{})
+}
+
+// Flush immediately performs a pending trailing invocation, if any, and ends the current burst.
+func (d *Debouncer) Flush() {
+	d.core.flush()
+}
+
+// Cancel drops a pending invocation and ends the current burst.
+func (d *Debouncer) Cancel() {
+	d.core.cancel()
+}
+
+// DebounceChan emits the latest value received from in once in has been quiet for wait.
+// The output channel is closed after in is closed (flushing a pending value) or ctx is done.
+func DebounceChan[T any](ctx context.Context, in <-chan T, wait time.Duration, options DebounceOptions) <-chan T {
+	return coalesceChan(ctx, in, wait, options)
+}
+
+// ThrottleChan emits the first value received from in and then at most one value, the latest, per interval.
+// The output channel is closed after in is closed (flushing a pending value) or ctx is done.
+func ThrottleChan[T any](ctx context.Context, in <-chan T, interval time.Duration, c clock.Clock) <-chan T {
+	return coalesceChan(ctx, in, interval, DebounceOptions{Edge: BothEdges, MaxWait: interval, Clock: c})
+}
+
+// coalesceChan forwards values from in to the returned channel through a debouncer.
+func coalesceChan[T any](ctx context.Context, in <-chan T, wait time.Duration, options DebounceOptions) <-chan T {
+	out := make(chan T)
+	stopped := false // Guarded by the debouncer's invocation lock
+	d := newDebouncer(func(v T) {
+		if stopped {
+			return
+		}
+		select {
+		case out <- v:
+		case <-ctx.Done():
+		}
+	}, wait, options)
+
+	go func() {
+		defer func() {
+			d.invokeMu.Lock()
+			stopped = true
+			close(out)
+			d.invokeMu.Unlock()
+		}()
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					d.flush()
+					return
+				}
+				d.call(v)
+			case <-ctx.Done():
+				d.cancel()
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// debouncer is the value-carrying state machine behind Debouncer and the channel helpers.
+type debouncer[T any] struct {
+	fn      func(T)
+	wait    time.Duration
+	options DebounceOptions
+
+	invokeMu sync.Mutex // Serializes invocations of fn
+
+	mu      sync.Mutex
+	active  bool        // Whether a burst is in progress
+	pending bool        // Whether a trailing invocation is owed
+	latest  T           // Argument of the latest call
+	anchor  time.Time   // Start of the burst or time of the last invocation, for MaxWait
+	timer   clock.Timer // Ends the current wait
+	gen     uint64      // Incremented whenever the timer is replaced so stale firings are ignored
+}
+
+// newDebouncer creates a debouncer for fn.
+func newDebouncer[T any](fn func(T), wait time.Duration, options DebounceOptions) *debouncer[T] {
+	if options.Clock == nil {
+		options.Clock = clock.Real
+	}
+	return &debouncer[T]{fn: fn, wait: wait, options: options}
+}
+
+// call records a call with argument v, invoking fn immediately if it starts a burst on the leading edge.
+func (d *debouncer[T]) call(v T) {
+	d.mu.Lock()
+	now := d.options.Clock.Now()
+	d.latest = v
+	invoke := false
+	if !d.active {
+		d.active = true
+		d.anchor = now
+		invoke = d.options.Edge != TrailingEdge
+	}
+	d.pending = !invoke
+	d.schedule(now)
+	d.mu.Unlock()
+
+	if invoke {
+		d.invoke(v)
+	}
+}
+
+// schedule re-arms the timer for the end of the current wait, bounded by MaxWait.
+func (d *debouncer[T]) schedule(now time.Time) {
+	delay := d.wait
+	if d.options.MaxWait > 0 {
+		delay = min(delay, max(d.anchor.Add(d.options.MaxWait).Sub(now), 0))
+	}
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	d.gen++
+	gen := d.gen
+	d.timer = d.options.Clock.AfterFunc(delay, func() { d.fire(gen) })
+}
+
+// fire ends a wait. A pending trailing invocation is performed and starts a new wait;
+// otherwise the burst is over.
+func (d *debouncer[T]) fire(gen uint64) {
+	d.mu.Lock()
+	if gen != d.gen {
+		d.mu.Unlock()
+		return
+	}
+	invoke := d.pending && d.options.Edge != LeadingEdge
+	v := d.latest
+	d.pending = false
+	if invoke {
+		now := d.options.Clock.Now()
+		d.anchor = now
+		d.schedule(now)
+	} else {
+		d.active = false
+		d.timer = nil
+	}
+	d.mu.Unlock()
+
+	if invoke {
+		d.invoke(v)
+	}
+}
+
+// flush performs a pending trailing invocation immediately and ends the burst.
+func (d *debouncer[T]) flush() {
+	d.mu.Lock()
+	invoke := d.pending && d.options.Edge != LeadingEdge
+	v := d.latest
+	d.reset()
+	d.mu.Unlock()
+
+	if invoke {
+		d.invoke(v)
+	}
+}
+
+// cancel drops a pending invocation and ends the burst.
+func (d *debouncer[T]) cancel() {
+	d.mu.Lock()
+	d.reset()
+	d.mu.Unlock()
+}
+
+// reset stops the timer and clears the burst state.
+func (d *debouncer[T]) reset() {
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	d.gen++
+	d.active, d.pending = false, false
+	var zero T
+	d.latest = zero
+}
+
+// invoke calls fn, never concurrently with another invocation.
+func (d *debouncer[T]) invoke(v T) {
+	d.invokeMu.Lock()
+	defer d.invokeMu.Unlock()
+	d.fn(v)
+}
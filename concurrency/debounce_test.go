@@ -0,0 +1,186 @@
+package concurrency
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vd09/go-generic-utils/clock"
+)
+
+func newTestClock() *clock.Fake {
+	return clock.NewFake(time.Unix(0, 0))
+}
+
+func TestDebounceTrailing(t *testing.T) {
+	c := newTestClock()
+	var calls int32
+	d := Debounce(func() { atomic.AddInt32(&calls, 1) }, 100*time.Millisecond, DebounceOptions{Clock: c})
+
+	for i := 0; i < 5; i++ {
+		d.Call()
+		c.Advance(50 * time.Millisecond)
+	}
+	assert.Equal(t, int32(0), calls, "Trailing debounce should wait for calls to stop")
+
+	c.Advance(50 * time.Millisecond)
+	assert.Equal(t, int32(1), calls, "Trailing debounce should invoke once after the quiet period")
+
+	c.Advance(time.Second)
+	assert.Equal(t, int32(1), calls, "No further invocations should happen without calls")
+}
+
+func TestDebounceLeading(t *testing.T) {
+	c := newTestClock()
+	var calls int32
+	d := Debounce(func() { atomic.AddInt32(&calls, 1) }, 100*time.Millisecond, DebounceOptions{Edge: LeadingEdge, Clock: c})
+
+	d.Call()
+	assert.Equal(t, int32(1), calls, "Leading debounce should invoke on the first call")
+	d.Call()
+	c.Advance(50 * time.Millisecond)
+	d.Call()
+	c.Advance(200 * time.Millisecond)
+	assert.Equal(t, int32(1), calls, "Leading debounce should ignore calls within the burst")
+
+	d.Call()
+	assert.Equal(t, int32(2), calls, "A new burst should invoke again")
+}
+
+func TestDebounceBothEdges(t *testing.T) {
+	c := newTestClock()
+	var calls int32
+	d := Debounce(func() { atomic.AddInt32(&calls, 1) }, 100*time.Millisecond, DebounceOptions{Edge: BothEdges, Clock: c})
+
+	d.Call()
+	c.Advance(100 * time.Millisecond)
+	assert.Equal(t, int32(1), calls, "A single call should only invoke on the leading edge")
+
+	c.Advance(time.Second)
+	d.Call()
+	d.Call()
+	c.Advance(100 * time.Millisecond)
+	assert.Equal(t, int32(3), calls, "A burst should invoke on both edges")
+}
+
+func TestDebounceMaxWait(t *testing.T) {
+	c := newTestClock()
+	var calls int32
+	d := Debounce(func() { atomic.AddInt32(&calls, 1) }, 100*time.Millisecond, DebounceOptions{MaxWait: 250 * time.Millisecond, Clock: c})
+
+	for i := 0; i < 5; i++ {
+		d.Call()
+		c.Advance(60 * time.Millisecond)
+	}
+	assert.Equal(t, int32(1), calls, "MaxWait should force an invocation during a long burst")
+}
+
+func TestDebounceFlushAndCancel(t *testing.T) {
+	c := newTestClock()
+	var calls int32
+	d := Debounce(func() { atomic.AddInt32(&calls, 1) }, 100*time.Millisecond, DebounceOptions{Clock: c})
+
+	d.Call()
+	d.Flush()
+	assert.Equal(t, int32(1), calls, "Flush should invoke a pending call immediately")
+	c.Advance(time.Second)
+	assert.Equal(t, int32(1), calls, "Flushed call should not be invoked again")
+
+	d.Call()
+	d.Cancel()
+	c.Advance(time.Second)
+	assert.Equal(t, int32(1), calls, "Cancelled call should not be invoked")
+
+	d.Flush()
+	assert.Equal(t, int32(1), calls, "Flush without a pending call should do nothing")
+}
+
+func TestThrottle(t *testing.T) {
+	c := newTestClock()
+	var calls int32
+	th := Throttle(func() { atomic.AddInt32(&calls, 1) }, 100*time.Millisecond, c)
+
+	for i := 0; i < 10; i++ {
+		th.Call()
+		c.Advance(25 * time.Millisecond)
+	}
+	// Invocations at 0ms, 100ms, 200ms and the trailing one at 300ms.
+	c.Advance(time.Second)
+	assert.Equal(t, int32(4), calls, "Throttle should invoke at most once per interval")
+}
+
+func TestDebounceConcurrentCalls(t *testing.T) {
+	var calls, running int32
+	d := Debounce(func() {
+		assert.Equal(t, int32(1), atomic.AddInt32(&running, 1), "Invocations should not overlap")
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		atomic.AddInt32(&calls, 1)
+	}, 5*time.Millisecond, DebounceOptions{Edge: BothEdges})
+
+	done := make(chan struct{})
+	for g := 0; g < 8; g++ {
+		go func() {
+			for i := 0; i < 50; i++ {
+				d.Call()
+			}
+			done <- struct{}{}
+		}()
+	}
+	for g := 0; g < 8; g++ {
+		<-done
+	}
+	d.Flush()
+	assert.GreaterOrEqual(t, atomic.LoadInt32(&calls), int32(1))
+}
+
+func TestDebounceChan(t *testing.T) {
+	in := make(chan int)
+	out := DebounceChan(context.Background(), in, 20*time.Millisecond, DebounceOptions{})
+
+	go func() {
+		for i := 1; i <= 5; i++ {
+			in <- i
+		}
+		time.Sleep(60 * time.Millisecond)
+		in <- 6
+		close(in)
+	}()
+
+	var got []int
+	for v := range out {
+		got = append(got, v)
+	}
+	assert.Equal(t, []int{5, 6}, got, "DebounceChan should emit the latest value of each burst and flush on close")
+}
+
+func TestThrottleChan(t *testing.T) {
+	in := make(chan int)
+	out := ThrottleChan(context.Background(), in, 50*time.Millisecond, nil)
+
+	go func() {
+		for i := 1; i <= 5; i++ {
+			in <- i
+		}
+		close(in)
+	}()
+
+	var got []int
+	for v := range out {
+		got = append(got, v)
+	}
+	assert.Equal(t, []int{1, 5}, got, "ThrottleChan should emit the first value and the latest of the burst")
+}
+
+func TestDebounceChanContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int)
+	out := DebounceChan(ctx, in, time.Hour, DebounceOptions{})
+
+	in <- 1
+	cancel()
+	_, ok := <-out
+	assert.False(t, ok, "Output should close without a value when the context is cancelled")
+}
@@ -0,0 +1,119 @@
+//go:build linux
+
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	fileSemaphoreMinBackoff = time.Millisecond      // Initial delay between lock attempts
+	fileSemaphoreMaxBackoff = 50 * time.Millisecond // Upper bound on the delay between lock attempts
+)
+
+// FileSemaphore is a semaphore shared by processes on the same host, backed by one flock(2)-locked file per slot.
+// A slot held by a process that exits or crashes is released by the kernel when its file descriptor is closed.
+type FileSemaphore struct {
+	paths []string   // Lock file of each slot
+	mu    sync.Mutex // Guards held
+	held  []*os.File // Lock files held by this instance, most recent last
+}
+
+// NewFileSemaphore creates a FileSemaphore with the given number of slots, using lock files named
+// "<name>.<slot>.lock" in dir. Every process sharing the cap must use the same dir, name and slots.
+func NewFileSemaphore(dir, name string, slots int) (*FileSemaphore, error) {
+	if slots <= 0 {
+		return nil, fmt.Errorf("concurrency: file semaphore needs at least one slot, got %d", slots)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("concurrency: create lock directory: %w", err)
+	}
+
+	paths := make([]string, slots)
+	for i := range paths {
+		paths[i] = filepath.Join(dir, fmt.Sprintf("%s.%d.lock", name, i))
+	}
+	return &FileSemaphore{paths: paths}, nil
+}
+
+// Acquire acquires a slot. Blocks if no slots are available.
+func (s *FileSemaphore) Acquire() error {
+	return s.AcquireContext(context.Background())
+}
+
+// AcquireContext acquires a slot, blocking until one is available or ctx is done.
+func (s *FileSemaphore) AcquireContext(ctx context.Context) error {
+	backoff := fileSemaphoreMinBackoff
+	for {
+		ok, err := s.TryAcquire()
+		if err != nil || ok {
+			return err
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		backoff = min(2*backoff, fileSemaphoreMaxBackoff)
+	}
+}
+
+// TryAcquire acquires a slot without blocking. It returns false if all slots are held.
+func (s *FileSemaphore) TryAcquire() (bool, error) {
+	for _, path := range s.paths {
+		f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+		if err != nil {
+			return false, fmt.Errorf("concurrency: open lock file: %w", err)
+		}
+
+		err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err == nil {
+			s.mu.Lock()
+			s.held = append(s.held, f)
+			s.mu.Unlock()
+			return true, nil
+		}
+		f.Close()
+		if err != syscall.EWOULDBLOCK {
+			return false, fmt.Errorf("concurrency: lock %s: %w", path, err)
+		}
+	}
+	return false, nil
+}
+
+// Release releases the most recently acquired slot held by this instance.
+// It returns ErrUnbalancedRelease if this instance holds no slot.
+func (s *FileSemaphore) Release() error {
+	s.mu.Lock()
+	n := len(s.held)
+	if n == 0 {
+		s.mu.Unlock()
+		return ErrUnbalancedRelease
+	}
+	f := s.held[n-1]
+	s.held = s.held[:n-1]
+	s.mu.Unlock()
+
+	// Closing the descriptor drops the lock even if the explicit unlock fails.
+	unlockErr := syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return unlockErr
+}
+
+// Held returns the number of slots currently held by this instance.
+func (s *FileSemaphore) Held() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.held)
+}
@@ -0,0 +1,90 @@
+//go:build linux
+
+package concurrency
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFileSemaphoreInvalidSlots(t *testing.T) {
+	_, err := NewFileSemaphore(t.TempDir(), "build", 0)
+	assert.Error(t, err, "NewFileSemaphore should reject a non-positive slot count")
+}
+
+func TestFileSemaphoreAcquireRelease(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewFileSemaphore(dir, "build", 2)
+	assert.NoError(t, err)
+
+	assert.NoError(t, s.Acquire())
+	assert.NoError(t, s.Acquire())
+	assert.Equal(t, 2, s.Held(), "Both slots should be held")
+
+	ok, err := s.TryAcquire()
+	assert.NoError(t, err)
+	assert.False(t, ok, "TryAcquire should fail when all slots are held")
+
+	assert.NoError(t, s.Release())
+	ok, err = s.TryAcquire()
+	assert.NoError(t, err)
+	assert.True(t, ok, "TryAcquire should succeed after a release")
+
+	assert.NoError(t, s.Release())
+	assert.NoError(t, s.Release())
+	assert.ErrorIs(t, s.Release(), ErrUnbalancedRelease, "Release without a held slot should be reported")
+}
+
+func TestFileSemaphoreSharedBetweenInstances(t *testing.T) {
+	dir := t.TempDir()
+	a, err := NewFileSemaphore(dir, "build", 1)
+	assert.NoError(t, err)
+	b, err := NewFileSemaphore(dir, "build", 1)
+	assert.NoError(t, err)
+
+	assert.NoError(t, a.Acquire())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, b.AcquireContext(ctx), context.DeadlineExceeded, "Second instance should block while the slot is held")
+
+	done := make(chan error, 1)
+	go func() { done <- b.Acquire() }()
+	time.Sleep(20 * time.Millisecond)
+	assert.NoError(t, a.Release())
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err, "Second instance should acquire after the slot is released")
+	case <-time.After(time.Second):
+		t.Fatal("Second instance did not acquire the released slot")
+	}
+	assert.NoError(t, b.Release())
+}
+
+func TestFileSemaphoreReleasedOnProcessExit(t *testing.T) {
+	if dir := os.Getenv("FILE_SEMAPHORE_CHILD_DIR"); dir != "" {
+		s, err := NewFileSemaphore(dir, "build", 1)
+		if err != nil || s.Acquire() != nil {
+			os.Exit(2)
+		}
+		os.Exit(0) // Exit without releasing the slot
+	}
+
+	dir := t.TempDir()
+	cmd := exec.Command(os.Args[0], "-test.run=^TestFileSemaphoreReleasedOnProcessExit$")
+	cmd.Env = append(os.Environ(), "FILE_SEMAPHORE_CHILD_DIR="+dir)
+	assert.NoError(t, cmd.Run(), "Child process should acquire the slot and exit")
+
+	s, err := NewFileSemaphore(dir, "build", 1)
+	assert.NoError(t, err)
+	ok, err := s.TryAcquire()
+	assert.NoError(t, err)
+	assert.True(t, ok, "Slot held by an exited process should be available")
+	assert.NoError(t, s.Release())
+}
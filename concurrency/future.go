@@ -0,0 +1,276 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	// ErrFuturePanicked is wrapped by the error of a future whose function panicked.
+	ErrFuturePanicked = errors.New("concurrency: future function panicked")
+	// ErrNoFutures is returned by Any and Race when called without futures.
+	ErrNoFutures = errors.New("concurrency: no futures to wait on")
+)
+
+// Future is the eventual result of an asynchronous computation.
+// A future completes with ctx.Err() as soon as the context it was created with is done.
+type Future[T any] struct {
+	ctx  context.Context
+	once sync.Once
+	done chan struct{}
+	val  T
+	err  error
+}
+
+// Outcome is the settled result of a single future, as reported by AllSettled.
+type Outcome[T any] struct {
+	Value T
+	Err   error
+}
+
+// newFuture creates a pending future that is completed with ctx.Err() when ctx is done.
+func newFuture[T any](ctx context.Context) *Future[T] {
+	f := &Future[T]{ctx: ctx, done: make(chan struct{})}
+	if ctx.Done() != nil {
+		go func() {
+			select {
+			case <-ctx.Done():
+				var zero T
+				f.complete(zero, ctx.Err())
+			case <-f.done:
+			}
+		}()
+	}
+	return f
+}
+
+// complete settles the future. Only the first call has an effect.
+func (f *Future[T]) complete(val T, err error) {
+	f.once.Do(func() {
+		f.val, f.err = val, err
+		close(f.done)
+	})
+}
+
+// Done returns a channel that is closed when the future completes.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Await blocks until the future completes or ctx is done and returns the result.
+func (f *Future[T]) Await(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.val, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Resolved returns a future that has already completed with val.
+func Resolved[T any](val T) *Future[T] {
+	f := newFuture[T](context.Background())
+	f.complete(val, nil)
+	return f
+}
+
+// Rejected returns a future that has already completed with err.
+func Rejected[T any](err error) *Future[T] {
+	f := newFuture[T](context.Background())
+	var zero T
+	f.complete(zero, err)
+	return f
+}
+
+// Async runs fn on a new goroutine and returns a future for its result.
+func Async[T any](ctx context.Context, fn func(ctx context.Context) (T, error)) *Future[T] {
+	f := newFuture[T](ctx)
+	go f.run(fn)
+	return f
+}
+
+// AsyncOn runs fn on pool and returns a future for its result.
+// It blocks while the pool's task queue is full and must not be called after pool.Wait.
+func AsyncOn[T any](ctx context.Context, pool *WorkerPool, fn func(ctx context.Context) (T, error)) *Future[T] {
+	f := newFuture[T](ctx)
+	pool.AddTask(func() { f.run(fn) })
+	return f
+}
+
+// AsyncBounded runs fn on a new goroutine once a slot of sem is available and returns a future for its result.
+func AsyncBounded[T any](ctx context.Context, sem *Semaphore, fn func(ctx context.Context) (T, error)) *Future[T] {
+	f := newFuture[T](ctx)
+	go func() {
+		select {
+		case <-f.done:
+			return // Already settled, e.g. by ctx; do not take a slot for nothing
+		default:
+		}
+		if err := sem.AcquireContext(ctx); err != nil {
+			var zero T
+			f.complete(zero, err)
+			return
+		}
+		defer sem.Release()
+		f.run(fn)
+	}()
+	return f
+}
+
+// run calls fn unless the future is already settled and completes the future with its result.
+func (f *Future[T]) run(fn func(ctx context.Context) (T, error)) {
+	select {
+	case <-f.done:
+		return
+	default:
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			var zero T
+			f.complete(zero, fmt.Errorf("%w: %v", ErrFuturePanicked, r))
+		}
+	}()
+	val, err := fn(f.ctx)
+	f.complete(val, err)
+}
+
+// Then returns a future that runs fn with the value of f once f succeeds.
+// An error from f is passed through without calling fn.
+func Then[T, U any](f *Future[T], fn func(ctx context.Context, val T) (U, error)) *Future[U] {
+	next := newFuture[U](f.ctx)
+	go func() {
+		select {
+		case <-f.done:
+		case <-next.done:
+			return
+		}
+		if f.err != nil {
+			var zero U
+			next.complete(zero, f.err)
+			return
+		}
+		next.run(func(ctx context.Context) (U, error) { return fn(ctx, f.val) })
+	}()
+	return next
+}
+
+// Map returns a future holding fn applied to the value of f.
+func Map[T, U any](f *Future[T], fn func(val T) U) *Future[U] {
+	return Then(f, func(_ context.Context, val T) (U, error) { return fn(val), nil })
+}
+
+// All returns a future holding the values of all futures in order.
+// It fails with the first error reported by any of the futures.
+func All[T any](ctx context.Context, futures ...*Future[T]) *Future[[]T] {
+	out := newFuture[[]T](ctx)
+	values := make([]T, len(futures))
+	var mu sync.Mutex
+	remaining := len(futures)
+	if remaining == 0 {
+		out.complete(values, nil)
+	}
+
+	for i, f := range futures {
+		go func() {
+			select {
+			case <-f.done:
+			case <-out.done:
+				return
+			}
+			if f.err != nil {
+				out.complete(nil, f.err)
+				return
+			}
+			mu.Lock()
+			values[i] = f.val
+			remaining--
+			finished := remaining == 0
+			mu.Unlock()
+			if finished {
+				out.complete(values, nil)
+			}
+		}()
+	}
+	return out
+}
+
+// AllSettled returns a future holding the outcome of every future in order, once all of them have completed.
+func AllSettled[T any](ctx context.Context, futures ...*Future[T]) *Future[[]Outcome[T]] {
+	out := newFuture[[]Outcome[T]](ctx)
+	go func() {
+		outcomes := make([]Outcome[T], len(futures))
+		for i, f := range futures {
+			select {
+			case <-f.done:
+				outcomes[i] = Outcome[T]{Value: f.val, Err: f.err}
+			case <-out.done:
+				return
+			}
+		}
+		out.complete(outcomes, nil)
+	}()
+	return out
+}
+
+// Any returns a future holding the value of the first future to succeed.
+// If every future fails, it fails with all their errors joined.
+func Any[T any](ctx context.Context, futures ...*Future[T]) *Future[T] {
+	out := newFuture[T](ctx)
+	if len(futures) == 0 {
+		var zero T
+		out.complete(zero, ErrNoFutures)
+		return out
+	}
+
+	errs := make([]error, len(futures))
+	var mu sync.Mutex
+	remaining := len(futures)
+	for i, f := range futures {
+		go func() {
+			select {
+			case <-f.done:
+			case <-out.done:
+				return
+			}
+			if f.err == nil {
+				out.complete(f.val, nil)
+				return
+			}
+			mu.Lock()
+			errs[i] = f.err
+			remaining--
+			failed := remaining == 0
+			mu.Unlock()
+			if failed {
+				var zero T
+				out.complete(zero, errors.Join(errs...))
+			}
+		}()
+	}
+	return out
+}
+
+// Race returns a future that settles like the first of futures to complete, whether it succeeds or fails.
+func Race[T any](ctx context.Context, futures ...*Future[T]) *Future[T] {
+	out := newFuture[T](ctx)
+	if len(futures) == 0 {
+		var zero T
+		out.complete(zero, ErrNoFutures)
+		return out
+	}
+
+	for _, f := range futures {
+		go func() {
+			select {
+			case <-f.done:
+				out.complete(f.val, f.err)
+			case <-out.done:
+			}
+		}()
+	}
+	return out
+}
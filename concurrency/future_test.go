@@ -0,0 +1,189 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func delayed[T any](d time.Duration, val T, err error) func(ctx context.Context) (T, error) {
+	return func(ctx context.Context) (T, error) {
+		select {
+		case <-time.After(d):
+			return val, err
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
+
+func TestFutureAsync(t *testing.T) {
+	f := Async(context.Background(), delayed(10*time.Millisecond, 5, nil))
+
+	v, err := f.Await(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 5, v, "Await should return the computed value")
+
+	select {
+	case <-f.Done():
+	default:
+		t.Fatal("Done should be closed after completion")
+	}
+}
+
+func TestFutureAwaitContext(t *testing.T) {
+	f := Async(context.Background(), delayed(time.Second, 1, nil))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := f.Await(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Await should stop waiting when its context expires")
+}
+
+func TestFutureContextPropagation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := Async(ctx, func(ctx context.Context) (int, error) {
+		time.Sleep(time.Second) // Ignores ctx on purpose
+		return 1, nil
+	})
+	next := Map(f, func(v int) int { return v + 1 })
+
+	cancel()
+	_, err := next.Await(context.Background())
+	assert.ErrorIs(t, err, context.Canceled, "Cancellation should propagate to derived futures")
+	_, err = f.Await(context.Background())
+	assert.ErrorIs(t, err, context.Canceled, "Cancellation should complete the future even if fn ignores ctx")
+}
+
+func TestFuturePanic(t *testing.T) {
+	f := Async(context.Background(), func(context.Context) (int, error) { panic("boom") })
+
+	_, err := f.Await(context.Background())
+	assert.ErrorIs(t, err, ErrFuturePanicked, "A panic should complete the future with an error")
+}
+
+func TestFutureThenAndMap(t *testing.T) {
+	f := Async(context.Background(), delayed(5*time.Millisecond, 21, nil))
+	doubled := Map(f, func(v int) int { return v * 2 })
+	text := Then(doubled, func(_ context.Context, v int) (string, error) { return strconv.Itoa(v), nil })
+
+	v, err := text.Await(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "42", v, "Then and Map should chain transformations")
+
+	errBoom := errors.New("boom")
+	called := false
+	failed := Then(Rejected[int](errBoom), func(_ context.Context, v int) (int, error) {
+		called = true
+		return v, nil
+	})
+	_, err = failed.Await(context.Background())
+	assert.ErrorIs(t, err, errBoom, "Then should pass errors through")
+	assert.False(t, called, "Then should not call fn after an error")
+}
+
+func TestFutureAsyncOn(t *testing.T) {
+	pool := NewWorkerPool(2, 4)
+	futures := make([]*Future[int], 4)
+	for i := range futures {
+		futures[i] = AsyncOn(context.Background(), pool, delayed(5*time.Millisecond, i, nil))
+	}
+
+	values, err := All(context.Background(), futures...).Await(context.Background())
+	pool.Wait()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3}, values, "Futures should run on the worker pool")
+}
+
+func TestFutureAsyncBounded(t *testing.T) {
+	sem := NewSemaphore(2)
+	var running, maxRunning int32
+	fn := func(context.Context) (int, error) {
+		cur := atomic.AddInt32(&running, 1)
+		for {
+			prev := atomic.LoadInt32(&maxRunning)
+			if cur <= prev || atomic.CompareAndSwapInt32(&maxRunning, prev, cur) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return 1, nil
+	}
+
+	futures := make([]*Future[int], 6)
+	for i := range futures {
+		futures[i] = AsyncBounded(context.Background(), sem, fn)
+	}
+	_, err := All(context.Background(), futures...).Await(context.Background())
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(2), "Semaphore should bound concurrent futures")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = AsyncBounded(ctx, sem, fn).Await(context.Background())
+	assert.ErrorIs(t, err, context.Canceled, "A cancelled context should fail the future")
+	sem.Wait()
+}
+
+func TestFutureAll(t *testing.T) {
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+
+	values, err := All(ctx, Resolved(1), Async(ctx, delayed(5*time.Millisecond, 2, nil))).Await(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, values)
+
+	_, err = All(ctx, Async(ctx, delayed(time.Second, 1, nil)), Rejected[int](errBoom)).Await(ctx)
+	assert.ErrorIs(t, err, errBoom, "All should fail fast on the first error")
+
+	values, err = All[int](ctx).Await(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, values, "All with no futures should succeed immediately")
+}
+
+func TestFutureAllSettled(t *testing.T) {
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+
+	outcomes, err := AllSettled(ctx, Resolved(1), Rejected[int](errBoom)).Await(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []Outcome[int]{{Value: 1}, {Err: errBoom}}, outcomes, "AllSettled should report every outcome in order")
+}
+
+func TestFutureAny(t *testing.T) {
+	ctx := context.Background()
+	errA, errB := errors.New("a"), errors.New("b")
+
+	v, err := Any(ctx, Rejected[int](errA), Async(ctx, delayed(5*time.Millisecond, 7, nil))).Await(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, v, "Any should return the first success")
+
+	_, err = Any(ctx, Rejected[int](errA), Rejected[int](errB)).Await(ctx)
+	assert.ErrorIs(t, err, errA, "Any should join all errors when every future fails")
+	assert.ErrorIs(t, err, errB, "Any should join all errors when every future fails")
+
+	_, err = Any[int](ctx).Await(ctx)
+	assert.ErrorIs(t, err, ErrNoFutures)
+}
+
+func TestFutureRace(t *testing.T) {
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+
+	v, err := Race(ctx, Async(ctx, delayed(time.Second, 1, nil)), Async(ctx, delayed(5*time.Millisecond, 2, nil))).Await(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v, "Race should settle with the fastest future")
+
+	_, err = Race(ctx, Async(ctx, delayed(time.Second, 1, nil)), Rejected[int](errBoom)).Await(ctx)
+	assert.ErrorIs(t, err, errBoom, "Race should settle with the first failure too")
+
+	_, err = Race[int](ctx).Await(ctx)
+	assert.ErrorIs(t, err, ErrNoFutures)
+}
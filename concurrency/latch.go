@@ -0,0 +1,59 @@
+package concurrency
+
+import (
+	"context"
+	"sync"
+)
+
+// CountDownLatch lets goroutines wait until a fixed number of events have happened.
+// Once the count reaches zero the latch stays open; it cannot be reset.
+type CountDownLatch struct {
+	mu    sync.Mutex
+	count int
+	done  chan struct{}
+}
+
+// NewCountDownLatch creates a latch that opens after count calls to CountDown.
+func NewCountDownLatch(count int) *CountDownLatch {
+	l := &CountDownLatch{count: count, done: make(chan struct{})}
+	if count <= 0 {
+		l.count = 0
+		close(l.done)
+	}
+	return l
+}
+
+// CountDown decrements the count, opening the latch when it reaches zero. Calls after that have no effect.
+func (l *CountDownLatch) CountDown() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.count == 0 {
+		return
+	}
+	l.count--
+	if l.count == 0 {
+		close(l.done)
+	}
+}
+
+// Count returns the number of CountDown calls still needed to open the latch.
+func (l *CountDownLatch) Count() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.count
+}
+
+// Done returns a channel that is closed when the latch opens.
+func (l *CountDownLatch) Done() <-chan struct{} {
+	return l.done
+}
+
+// Await blocks until the latch opens or ctx is done.
+func (l *CountDownLatch) Await(ctx context.Context) error {
+	select {
+	case <-l.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
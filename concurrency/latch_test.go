@@ -0,0 +1,42 @@
+package concurrency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountDownLatch(t *testing.T) {
+	l := NewCountDownLatch(3)
+
+	for i := 0; i < 3; i++ {
+		go l.CountDown()
+	}
+	assert.NoError(t, l.Await(context.Background()), "Await should return once the count reaches zero")
+	assert.Equal(t, 0, l.Count())
+
+	l.CountDown()
+	assert.Equal(t, 0, l.Count(), "CountDown on an open latch should have no effect")
+}
+
+func TestCountDownLatchAwaitTimeout(t *testing.T) {
+	l := NewCountDownLatch(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, l.Await(ctx), context.DeadlineExceeded, "Await should stop when the context expires")
+	assert.Equal(t, 1, l.Count())
+
+	select {
+	case <-l.Done():
+		t.Fatal("Done should not be closed before the count reaches zero")
+	default:
+	}
+}
+
+func TestCountDownLatchZero(t *testing.T) {
+	l := NewCountDownLatch(0)
+	assert.NoError(t, l.Await(context.Background()), "A latch with a zero count should start open")
+}
@@ -0,0 +1,145 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrPhaserTerminated is returned by a Phaser once all its parties have deregistered.
+var ErrPhaserTerminated = errors.New("concurrency: phaser is terminated")
+
+// Phaser is a reusable barrier whose number of registered parties may change between and during phases.
+// A phase advances once every registered party has arrived. The phaser terminates when its last party deregisters.
+type Phaser struct {
+	mu         sync.Mutex
+	phase      int
+	parties    int
+	arrived    int
+	advance    chan struct{} // Closed when the current phase advances
+	terminated bool
+}
+
+// NewPhaser creates a Phaser at phase 0 with parties registered parties.
+func NewPhaser(parties int) *Phaser {
+	return &Phaser{parties: parties, advance: make(chan struct{})}
+}
+
+// Register adds a party, which must arrive before the current phase can advance. It returns the current phase.
+func (p *Phaser) Register() (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.terminated {
+		return p.phase, ErrPhaserTerminated
+	}
+	p.parties++
+	return p.phase, nil
+}
+
+// Arrive records the arrival of a party without waiting for the others. It returns the phase arrived at.
+func (p *Phaser) Arrive() (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.terminated {
+		return p.phase, ErrPhaserTerminated
+	}
+	phase := p.phase
+	p.arrived++
+	p.maybeAdvanceLocked()
+	return phase, nil
+}
+
+// ArriveAndDeregister records the arrival of a party and removes it for later phases. It returns the phase arrived at.
+// Deregistering the last party terminates the phaser.
+func (p *Phaser) ArriveAndDeregister() (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.terminated {
+		return p.phase, ErrPhaserTerminated
+	}
+	phase := p.phase
+	p.parties--
+	p.maybeAdvanceLocked()
+	return phase, nil
+}
+
+// ArriveAndAwait records the arrival of a party and waits for the others. It returns the new phase number.
+// If ctx is done first, ctx.Err() is returned but the arrival still counts.
+func (p *Phaser) ArriveAndAwait(ctx context.Context) (int, error) {
+	p.mu.Lock()
+	if p.terminated {
+		defer p.mu.Unlock()
+		return p.phase, ErrPhaserTerminated
+	}
+	phase := p.phase
+	p.arrived++
+	p.maybeAdvanceLocked()
+	p.mu.Unlock()
+
+	return p.AwaitAdvance(ctx, phase)
+}
+
+// AwaitAdvance waits until the phaser moves past phase and returns the new phase number.
+// It returns immediately if the current phase already differs from phase.
+func (p *Phaser) AwaitAdvance(ctx context.Context, phase int) (int, error) {
+	p.mu.Lock()
+	current, advance, terminated := p.phase, p.advance, p.terminated
+	p.mu.Unlock()
+	if terminated {
+		return current, ErrPhaserTerminated
+	}
+	if current != phase {
+		return current, nil
+	}
+
+	// Each phase has its own advance channel, so its closing means exactly this phase completed.
+	select {
+	case <-advance:
+		return phase + 1, nil
+	case <-ctx.Done():
+		return phase, ctx.Err()
+	}
+}
+
+// Phase returns the current phase number.
+func (p *Phaser) Phase() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.phase
+}
+
+// Parties returns the number of registered parties.
+func (p *Phaser) Parties() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.parties
+}
+
+// Arrived returns the number of parties that have arrived at the current phase.
+func (p *Phaser) Arrived() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.arrived
+}
+
+// IsTerminated reports whether all parties have deregistered.
+func (p *Phaser) IsTerminated() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.terminated
+}
+
+// maybeAdvanceLocked advances the phase once every registered party has arrived.
+func (p *Phaser) maybeAdvanceLocked() {
+	if p.arrived < p.parties && p.parties > 0 {
+		return
+	}
+	p.phase++
+	p.arrived = 0
+	close(p.advance)
+	p.advance = make(chan struct{})
+	if p.parties <= 0 {
+		p.parties = 0
+		p.terminated = true
+	}
+}
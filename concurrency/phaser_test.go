@@ -0,0 +1,93 @@
+package concurrency
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhaserAdvancesPhases(t *testing.T) {
+	p := NewPhaser(3)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for phase := 0; phase < 3; phase++ {
+				next, err := p.ArriveAndAwait(context.Background())
+				assert.NoError(t, err)
+				assert.Equal(t, phase+1, next, "ArriveAndAwait should return the next phase")
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 3, p.Phase())
+}
+
+func TestPhaserDynamicParties(t *testing.T) {
+	p := NewPhaser(1)
+
+	phase, err := p.Register()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, phase)
+	assert.Equal(t, 2, p.Parties())
+
+	_, _ = p.Arrive()
+	assert.Equal(t, 0, p.Phase(), "Phase should not advance until every party arrives")
+	assert.Equal(t, 1, p.Arrived())
+
+	_, _ = p.ArriveAndDeregister()
+	assert.Equal(t, 1, p.Phase(), "Phase should advance once the remaining parties have arrived")
+	assert.Equal(t, 1, p.Parties(), "Deregistered party should not count in later phases")
+
+	_, _ = p.Arrive()
+	assert.Equal(t, 2, p.Phase())
+}
+
+func TestPhaserDeregisterReleasesWaiters(t *testing.T) {
+	p := NewPhaser(2)
+
+	done := make(chan int)
+	go func() {
+		next, _ := p.ArriveAndAwait(context.Background())
+		done <- next
+	}()
+	assert.Eventually(t, func() bool { return p.Arrived() == 1 }, time.Second, time.Millisecond)
+
+	_, _ = p.ArriveAndDeregister()
+	assert.Equal(t, 1, <-done, "Deregistering the last missing party should advance the phase")
+}
+
+func TestPhaserTermination(t *testing.T) {
+	p := NewPhaser(1)
+
+	_, err := p.ArriveAndDeregister()
+	assert.NoError(t, err)
+	assert.True(t, p.IsTerminated(), "Deregistering the last party should terminate the phaser")
+
+	_, err = p.Register()
+	assert.ErrorIs(t, err, ErrPhaserTerminated)
+	_, err = p.ArriveAndAwait(context.Background())
+	assert.ErrorIs(t, err, ErrPhaserTerminated)
+	_, err = p.AwaitAdvance(context.Background(), 0)
+	assert.ErrorIs(t, err, ErrPhaserTerminated)
+}
+
+func TestPhaserAwaitTimeout(t *testing.T) {
+	p := NewPhaser(2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	phase, err := p.ArriveAndAwait(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "ArriveAndAwait should stop when the context expires")
+	assert.Equal(t, 0, phase)
+	assert.Equal(t, 1, p.Arrived(), "A timed-out arrival should still count")
+
+	next, err := p.AwaitAdvance(context.Background(), 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, next, "AwaitAdvance for another phase should return immediately")
+}
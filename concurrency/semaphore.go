@@ -1,8 +1,11 @@
 package concurrency
 
 import (
+	"context"
+	"errors"
 	"reflect"
 	"sync"
+	"time"
 )
 
 // Semaphore is a struct that encapsulates a semaphore pattern for concurrency control.
@@ -10,6 +13,7 @@ type Semaphore struct {
 	maxGoroutines int
 	sem           chan struct{}
 	wg            sync.WaitGroup
+	debug         *semaphoreDebug // Non-nil when created with NewDebugSemaphore
 }
 
 // NewSemaphore creates a new Semaphore with a specified maximum number of concurrent goroutines.
@@ -22,12 +26,116 @@ func NewSemaphore(maxGoroutines int) *Semaphore {
 
 // Acquire acquires a semaphore slot. Blocks if no slots are available.
 func (s *Semaphore) Acquire() {
+	s.acquire()
+}
+
+// acquire takes a slot and returns the ID of its debug record, or 0 outside debug mode.
+func (s *Semaphore) acquire() uint64 {
 	s.wg.Add(1)
 	s.sem <- struct{}{}
+	if s.debug != nil {
+		return s.debug.acquired()
+	}
+	return 0
+}
+
+// AcquireContext acquires a semaphore slot, blocking until one is available or ctx is done.
+// A ctx that is already done fails without touching the semaphore.
+func (s *Semaphore) AcquireContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.wg.Add(1)
+	select {
+	case s.sem <- struct{}{}:
+	case <-ctx.Done():
+		s.wg.Done()
+		return ctx.Err()
+	}
+	if s.debug != nil {
+		s.debug.acquired()
+	}
+	return nil
+}
+
+// TryAcquire acquires a semaphore slot if one is available, without blocking.
+func (s *Semaphore) TryAcquire() bool {
+	s.wg.Add(1) // Counted before the slot is taken so Wait never misses it
+	select {
+	case s.sem <- struct{}{}:
+		s.recordAcquire()
+		return true
+	default:
+		s.wg.Done()
+		return false
+	}
+}
+
+// errAcquireTimeout is returned by acquireEither when its timeout fires first.
+var errAcquireTimeout = errors.New("concurrency: semaphore acquire timed out")
+
+// acquireEither takes a slot from primary or, if it is non-nil, from secondary, preferring primary whenever
+// it has a free slot. It blocks until one of them frees a slot, timeout fires or ctx is done, and returns
+// the semaphore it acquired from. A nil timeout never fires.
+func acquireEither(ctx context.Context, timeout <-chan time.Time, primary, secondary *Semaphore) (*Semaphore, error) {
+	if primary.TryAcquire() {
+		return primary, nil
+	}
+	// A nil channel is never ready, which disables the secondary semaphore when there is none.
+	var secondarySem chan struct{}
+	if secondary != nil {
+		if secondary.TryAcquire() {
+			return secondary, nil
+		}
+		secondarySem = secondary.sem
+		secondary.wg.Add(1)
+	}
+	primary.wg.Add(1)
+
+	var acquired *Semaphore
+	var err error
+	select {
+	case primary.sem <- struct{}{}:
+		acquired = primary
+	case secondarySem <- struct{}{}:
+		acquired = secondary
+	case <-timeout:
+		err = errAcquireTimeout
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	// Undo the WaitGroup counts of the semaphores that were not acquired.
+	for _, s := range []*Semaphore{primary, secondary} {
+		if s != nil && s != acquired {
+			s.wg.Done()
+		}
+	}
+	if acquired != nil {
+		acquired.recordAcquire()
+	}
+	return acquired, err
+}
+
+// recordAcquire records the calling goroutine as a holder in debug mode.
+func (s *Semaphore) recordAcquire() {
+	if s.debug != nil {
+		s.debug.acquired()
+	}
 }
 
 // Release releases a semaphore slot.
+// In debug mode an unbalanced Release is reported instead of blocking forever.
 func (s *Semaphore) Release() {
+	s.release(0)
+}
+
+// release frees a slot, removing the debug record with the given ID, or with ID 0 one of the calling goroutine.
+func (s *Semaphore) release(record uint64) {
+	if s.debug != nil && !s.debug.released(record) {
+		s.debug.misuse(ErrUnbalancedRelease)
+		return
+	}
 	<-s.sem
 	s.wg.Done()
 }
@@ -39,9 +147,9 @@ func (s *Semaphore) Wait() {
 
 // ProcessAndReleaseReflect processes a given function with arguments and releases the semaphore.
 func (s *Semaphore) ProcessAndReleaseReflect(fn interface{}, args ...interface{}) {
-	s.Acquire()
+	record := s.acquire()
 	go func() {
-		defer s.Release()
+		defer s.release(record)
 
 		// Use reflection to call the function with the provided arguments
 		fnValue := reflect.ValueOf(fn)
@@ -56,9 +164,9 @@ func (s *Semaphore) ProcessAndReleaseReflect(fn interface{}, args ...interface{}
 
 // ProcessAndRelease processes a given function with arguments and releases the semaphore.
 func (s *Semaphore) ProcessAndRelease(fn func()) {
-	s.Acquire()
+	record := s.acquire()
 	go func() {
-		defer s.Release()
+		defer s.release(record)
 		fn()
 	}()
 }
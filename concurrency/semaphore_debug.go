@@ -0,0 +1,158 @@
+package concurrency
+
+import (
+	"bytes"
+	"errors"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrUnbalancedRelease is reported when Release is called on a debug semaphore without a matching Acquire.
+var ErrUnbalancedRelease = errors.New("concurrency: semaphore released without a matching acquire")
+
+// SemaphoreHolder describes a goroutine currently holding a slot of a debug semaphore.
+type SemaphoreHolder struct {
+	GoroutineID uint64    // ID of the goroutine that called Acquire
+	AcquiredAt  time.Time // Time at which the slot was acquired
+	Stack       string    // Stack trace of the goroutine at acquire time
+}
+
+// HeldFor returns how long the slot has been held.
+func (h SemaphoreHolder) HeldFor() time.Duration {
+	return time.Since(h.AcquiredAt)
+}
+
+// semaphoreDebug records the holders of a semaphore in debug mode.
+type semaphoreDebug struct {
+	mu       sync.Mutex
+	nextID   uint64
+	held     int                        // Slots currently held
+	holders  map[uint64]SemaphoreHolder // Holder records by ID; may include records of released slots, see released
+	onMisuse func(err error)
+}
+
+// NewDebugSemaphore creates a Semaphore that records every holder with its goroutine stack and acquire time.
+// onMisuse is called on an unbalanced Release; when it is nil, an unbalanced Release panics with ErrUnbalancedRelease.
+func NewDebugSemaphore(maxGoroutines int, onMisuse func(err error)) *Semaphore {
+	s := NewSemaphore(maxGoroutines)
+	s.debug = &semaphoreDebug{
+		holders:  make(map[uint64]SemaphoreHolder),
+		onMisuse: onMisuse,
+	}
+	return s
+}
+
+// Holders returns the current holders of a debug semaphore, oldest first.
+// It returns nil if the semaphore was not created with NewDebugSemaphore.
+// A slot released by a goroutine other than the one that acquired it, outside ProcessAndRelease, cannot be
+// matched to its record, so the record is kept rather than dropping a possibly leaked one; such records are
+// cleared once every slot has been released.
+func (s *Semaphore) Holders() []SemaphoreHolder {
+	if s.debug == nil {
+		return nil
+	}
+	return s.debug.snapshot(0)
+}
+
+// HeldLongerThan returns the holders of a debug semaphore that have held their slot longer than threshold, oldest first.
+func (s *Semaphore) HeldLongerThan(threshold time.Duration) []SemaphoreHolder {
+	if s.debug == nil {
+		return nil
+	}
+	return s.debug.snapshot(threshold)
+}
+
+// acquired records the calling goroutine as a holder and returns the ID of the record.
+func (d *semaphoreDebug) acquired() uint64 {
+	stack := captureStack()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.nextID++
+	d.held++
+	d.holders[d.nextID] = SemaphoreHolder{
+		GoroutineID: goroutineID(stack),
+		AcquiredAt:  time.Now(),
+		Stack:       string(stack),
+	}
+	return d.nextID
+}
+
+// released removes the holder record with the given ID, or with ID 0 the oldest record of the calling
+// goroutine. If the caller holds no record, no record is removed, since guessing could hide a leak.
+// It returns false if no slot is held.
+func (d *semaphoreDebug) released(id uint64) bool {
+	var gid uint64
+	if id == 0 {
+		gid = goroutineID(captureStack())
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.held == 0 {
+		return false
+	}
+	d.held--
+
+	if id == 0 {
+		for candidate, h := range d.holders {
+			if h.GoroutineID == gid && (id == 0 || candidate < id) {
+				id = candidate
+			}
+		}
+	}
+	delete(d.holders, id)
+	if d.held == 0 {
+		clear(d.holders) // Any remaining records belong to slots released from other goroutines
+	}
+	return true
+}
+
+// misuse reports err through the configured callback or panics.
+func (d *semaphoreDebug) misuse(err error) {
+	if d.onMisuse == nil {
+		panic(err)
+	}
+	d.onMisuse(err)
+}
+
+// snapshot returns the holders that have held their slot longer than threshold, oldest first.
+func (d *semaphoreDebug) snapshot(threshold time.Duration) []SemaphoreHolder {
+	d.mu.Lock()
+	ids := make([]uint64, 0, len(d.holders))
+	for id, h := range d.holders {
+		if h.HeldFor() >= threshold {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	holders := make([]SemaphoreHolder, len(ids))
+	for i, id := range ids {
+		holders[i] = d.holders[id]
+	}
+	d.mu.Unlock()
+	return holders
+}
+
+// captureStack returns the stack trace of the calling goroutine.
+func captureStack() []byte {
+	buf := make([]byte, 4096)
+	for {
+		n := runtime.Stack(buf, false)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// goroutineID parses the goroutine ID from the header line of a stack trace.
+func goroutineID(stack []byte) uint64 {
+	stack = bytes.TrimPrefix(stack, []byte("goroutine "))
+	if i := bytes.IndexByte(stack, ' '); i > 0 {
+		stack = stack[:i]
+	}
+	id, _ := strconv.ParseUint(string(stack), 10, 64)
+	return id
+}
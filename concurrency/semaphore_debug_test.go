@@ -0,0 +1,117 @@
+package concurrency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugSemaphoreRecordsHolders(t *testing.T) {
+	s := NewDebugSemaphore(2, nil)
+
+	s.Acquire()
+	s.Acquire()
+
+	holders := s.Holders()
+	assert.Equal(t, 2, len(holders), "Debug semaphore should record every holder")
+	for _, h := range holders {
+		assert.NotZero(t, h.GoroutineID, "Holder should record the goroutine ID")
+		assert.Contains(t, h.Stack, "TestDebugSemaphoreRecordsHolders", "Holder should record the acquiring stack")
+	}
+
+	s.Release()
+	assert.Equal(t, 1, len(s.Holders()), "Release should remove a holder record")
+	s.Release()
+	assert.Empty(t, s.Holders(), "All holder records should be removed after balanced releases")
+}
+
+func TestDebugSemaphoreUnbalancedReleasePanics(t *testing.T) {
+	s := NewDebugSemaphore(1, nil)
+
+	assert.PanicsWithError(t, ErrUnbalancedRelease.Error(), func() { s.Release() }, "Unbalanced Release should panic by default")
+}
+
+func TestDebugSemaphoreUnbalancedReleaseCallback(t *testing.T) {
+	var reported error
+	s := NewDebugSemaphore(1, func(err error) { reported = err })
+
+	s.Acquire()
+	s.Release()
+	s.Release()
+
+	assert.ErrorIs(t, reported, ErrUnbalancedRelease, "Unbalanced Release should be reported to the callback")
+	assert.Equal(t, 0, len(s.sem), "Unbalanced Release should not change the slot count")
+}
+
+func TestDebugSemaphoreHeldLongerThan(t *testing.T) {
+	s := NewDebugSemaphore(3, nil)
+
+	s.Acquire()
+	time.Sleep(50 * time.Millisecond)
+	s.Acquire()
+
+	leaked := s.HeldLongerThan(40 * time.Millisecond)
+	assert.Equal(t, 1, len(leaked), "Only the old holder should exceed the threshold")
+	assert.GreaterOrEqual(t, leaked[0].HeldFor(), 40*time.Millisecond, "Reported holder should have been held past the threshold")
+	assert.Equal(t, 2, len(s.HeldLongerThan(0)), "A zero threshold should report every holder")
+}
+
+func TestDebugSemaphoreProcessAndRelease(t *testing.T) {
+	s := NewDebugSemaphore(2, nil)
+
+	for i := 0; i < 5; i++ {
+		s.ProcessAndRelease(func() {
+			time.Sleep(10 * time.Millisecond)
+		})
+	}
+
+	s.Wait()
+	assert.Empty(t, s.Holders(), "No holders should remain after all tasks complete")
+}
+
+func TestSemaphoreHoldersWithoutDebug(t *testing.T) {
+	s := NewSemaphore(1)
+	s.Acquire()
+
+	assert.Nil(t, s.Holders(), "Holders should be nil when debug mode is off")
+	assert.Nil(t, s.HeldLongerThan(0), "HeldLongerThan should be nil when debug mode is off")
+	s.Release()
+}
+
+func TestDebugSemaphoreReportsLeakAmongProcessAndRelease(t *testing.T) {
+	s := NewDebugSemaphore(3, nil)
+
+	s.Acquire() // Leaked: never released
+	for i := 0; i < 5; i++ {
+		s.ProcessAndRelease(func() {
+			time.Sleep(20 * time.Millisecond)
+		})
+		time.Sleep(20 * time.Millisecond)
+	}
+	time.Sleep(30 * time.Millisecond)
+
+	leaked := s.HeldLongerThan(90 * time.Millisecond)
+	if assert.Equal(t, 1, len(leaked), "The leaked slot should be reported despite other releases") {
+		assert.Contains(t, leaked[0].Stack, "TestDebugSemaphoreReportsLeakAmongProcessAndRelease", "The leaked record should point at the leaking caller")
+	}
+	assert.Equal(t, 1, len(s.Holders()), "Only the leaked slot should still be recorded")
+}
+
+func TestDebugSemaphoreCrossGoroutineReleaseKeepsRecords(t *testing.T) {
+	s := NewDebugSemaphore(2, nil)
+
+	s.Acquire() // Leaked
+	s.Acquire() // Handed off to another goroutine
+	done := make(chan struct{})
+	go func() {
+		s.Release()
+		close(done)
+	}()
+	<-done
+
+	assert.Equal(t, 2, len(s.Holders()), "A release that cannot be matched should not drop a possibly leaked record")
+
+	s.Release()
+	assert.Empty(t, s.Holders(), "Records should be cleared once every slot is released")
+}
@@ -1,6 +1,7 @@
 package concurrency
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"sync/atomic"
@@ -108,3 +109,73 @@ func TestSemaphoreConcurrentExecutionLimit(t *testing.T) {
 	s.Wait()
 	assert.Equal(t, int32(0), counter, "Counter should return to 0 after all tasks complete")
 }
+
+func TestSemaphoreAcquireContext(t *testing.T) {
+	s := NewSemaphore(1)
+
+	assert.NoError(t, s.AcquireContext(context.Background()), "AcquireContext should succeed when a slot is free")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, s.AcquireContext(ctx), context.DeadlineExceeded, "AcquireContext should give up when the context expires")
+	assert.Equal(t, 1, len(s.sem), "A failed AcquireContext should not take a slot")
+
+	s.Release()
+	s.Wait()
+
+	cancelled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	assert.ErrorIs(t, s.AcquireContext(cancelled), context.Canceled, "AcquireContext should fail fast on a done context even with free slots")
+	assert.Equal(t, 0, len(s.sem), "A done context should not take a slot")
+}
+
+func TestSemaphoreTryAcquire(t *testing.T) {
+	s := NewSemaphore(1)
+
+	assert.True(t, s.TryAcquire(), "TryAcquire should succeed when a slot is free")
+	assert.False(t, s.TryAcquire(), "TryAcquire should fail when all slots are held")
+
+	s.Release()
+	s.Wait()
+}
+
+func TestAcquireEitherPrefersPrimary(t *testing.T) {
+	primary, secondary := NewSemaphore(1), NewSemaphore(1)
+
+	for i := 0; i < 100; i++ {
+		sem, err := acquireEither(context.Background(), nil, primary, secondary)
+		assert.NoError(t, err)
+		assert.Same(t, primary, sem, "A free primary slot should always be taken before the secondary one")
+		primary.Release()
+	}
+
+	primary.Acquire()
+	sem, err := acquireEither(context.Background(), nil, primary, secondary)
+	assert.NoError(t, err)
+	assert.Same(t, secondary, sem, "The secondary semaphore should be used when the primary one is full")
+	secondary.Release()
+	primary.Release()
+	primary.Wait()
+	secondary.Wait()
+}
+
+func TestAcquireEitherTimeoutAndContext(t *testing.T) {
+	primary, secondary := NewSemaphore(1), NewSemaphore(1)
+	primary.Acquire()
+	secondary.Acquire()
+
+	sem, err := acquireEither(context.Background(), time.After(10*time.Millisecond), primary, secondary)
+	assert.ErrorIs(t, err, errAcquireTimeout, "acquireEither should report its timeout")
+	assert.Nil(t, sem)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	sem, err = acquireEither(ctx, nil, primary, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "acquireEither should stop when ctx is done")
+	assert.Nil(t, sem)
+
+	primary.Release()
+	secondary.Release()
+	primary.Wait()
+	secondary.Wait()
+}
@@ -1,29 +1,64 @@
 package concurrency
 
 import (
+	"context"
 	"sync"
+
+	"github.com/vd09/go-generic-utils/queue"
 )
 
 // Task is a type that represents a function to be executed by a worker.
 type Task func()
 
+// RateLimiter limits how often tasks are dispatched. Every limiter in the ratelimit package satisfies it.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
 // WorkerPool is a struct that manages a pool of workers to execute tasks concurrently.
 type WorkerPool struct {
 	tasks      chan Task
+	ring       *queue.MPMCQueue[Task] // Replaces tasks when created with NewRingWorkerPool
+	ctx        context.Context        // Cancelled by Wait to stop ring workers once the queue is drained
+	cancel     context.CancelFunc
 	wg         sync.WaitGroup
 	numWorkers int
+	limiter    RateLimiter // Optional limiter consulted before each task is run
+
+	mu         sync.Mutex
+	rejected   uint64 // Tasks dropped because the limiter refused them
+	limiterErr error  // Most recent error returned by the limiter
 }
 
 // NewWorkerPool creates a new WorkerPool with a specified number of workers and a maximum number of tasks in the queue.
 func NewWorkerPool(numWorkers int, maxTasks int) *WorkerPool {
-	pool := &WorkerPool{
-		tasks:      make(chan Task, maxTasks),
+	return newWorkerPool(&WorkerPool{tasks: make(chan Task, maxTasks), numWorkers: numWorkers})
+}
+
+// NewRateLimitedWorkerPool creates a WorkerPool whose workers wait on limiter before running each task,
+// capping both the number of concurrent tasks and the rate at which they start.
+// A task the limiter refuses, for example with ratelimit.ErrExceedsLimit, is dropped and reported by Rejected.
+func NewRateLimitedWorkerPool(numWorkers int, maxTasks int, limiter RateLimiter) *WorkerPool {
+	return newWorkerPool(&WorkerPool{tasks: make(chan Task, maxTasks), numWorkers: numWorkers, limiter: limiter})
+}
+
+// NewRingWorkerPool creates a WorkerPool whose task queue is a lock-free queue.MPMCQueue instead of a channel.
+// It suits many small tasks submitted from many goroutines; idle workers back off by polling, so a task
+// may start up to a fraction of a millisecond later than with a channel.
+func NewRingWorkerPool(numWorkers int, maxTasks int) *WorkerPool {
+	ctx, cancel := context.WithCancel(context.Background())
+	return newWorkerPool(&WorkerPool{
+		ring:       queue.NewMPMCQueue[Task](maxTasks),
+		ctx:        ctx,
+		cancel:     cancel,
 		numWorkers: numWorkers,
-		wg:         sync.WaitGroup{},
-	}
+	})
+}
 
+// newWorkerPool starts the workers of pool.
+func newWorkerPool(pool *WorkerPool) *WorkerPool {
 	// Start the worker goroutines
-	for i := 0; i < numWorkers; i++ {
+	for i := 0; i < pool.numWorkers; i++ {
 		pool.wg.Add(1)
 		go pool.worker()
 	}
@@ -31,24 +66,79 @@ func NewWorkerPool(numWorkers int, maxTasks int) *WorkerPool {
 	return pool
 }
 
-// worker is a function that is executed by each worker goroutine. It processes tasks from the tasks channel.
+// worker is a function that is executed by each worker goroutine. It processes tasks from the tasks queue.
 func (wp *WorkerPool) worker() {
 	defer wp.wg.Done()
 
+	if wp.ring != nil {
+		wp.ringWorker()
+		return
+	}
 	for task := range wp.tasks {
-		if task != nil {
-			task()
+		wp.run(task)
+	}
+}
+
+// ringWorker processes tasks from the ring queue until Wait is called and the queue is empty.
+func (wp *WorkerPool) ringWorker() {
+	for {
+		task, err := wp.ring.Dequeue(wp.ctx)
+		if err != nil {
+			// Wait was called: every task added before it is already visible, so drain and stop.
+			for {
+				task, ok := wp.ring.TryDequeue()
+				if !ok {
+					return
+				}
+				wp.run(task)
+			}
 		}
+		wp.run(task)
 	}
 }
 
+// run executes a single task, honouring the rate limiter if there is one.
+func (wp *WorkerPool) run(task Task) {
+	if task == nil {
+		return
+	}
+	if wp.limiter != nil {
+		// A background context never expires, so an error means the limiter will never admit the task.
+		if err := wp.limiter.Wait(context.Background()); err != nil {
+			wp.mu.Lock()
+			wp.rejected++
+			wp.limiterErr = err
+			wp.mu.Unlock()
+			return
+		}
+	}
+	task()
+}
+
+// Rejected returns the number of tasks dropped because the rate limiter refused them and the most recent
+// error it returned.
+func (wp *WorkerPool) Rejected() (uint64, error) {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	return wp.rejected, wp.limiterErr
+}
+
 // AddTask adds a new task to the worker pool for execution.
 func (wp *WorkerPool) AddTask(task Task) {
+	if wp.ring != nil {
+		// A background context never expires, so Enqueue only returns once the task is queued.
+		_ = wp.ring.Enqueue(context.Background(), task)
+		return
+	}
 	wp.tasks <- task
 }
 
 // Wait blocks until all tasks have been completed and all workers have stopped.
 func (wp *WorkerPool) Wait() {
-	close(wp.tasks) // Signal to the workers that no more tasks will be sent
-	wp.wg.Wait()    // Wait for all workers to finish
+	if wp.ring != nil {
+		wp.cancel() // Signal to the workers that no more tasks will be added
+	} else {
+		close(wp.tasks) // Signal to the workers that no more tasks will be sent
+	}
+	wp.wg.Wait() // Wait for all workers to finish
 }
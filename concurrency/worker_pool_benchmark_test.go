@@ -0,0 +1,31 @@
+package concurrency
+
+import (
+	"sync"
+	"testing"
+)
+
+// benchmarkWorkerPool submits b.N tiny tasks from parallel producers.
+func benchmarkWorkerPool(b *testing.B, wp *WorkerPool) {
+	var wg sync.WaitGroup
+	wg.Add(b.N)
+	task := func() { wg.Done() }
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			wp.AddTask(task)
+		}
+	})
+	wg.Wait()
+	b.StopTimer()
+	wp.Wait()
+}
+
+func BenchmarkWorkerPoolChannel(b *testing.B) {
+	benchmarkWorkerPool(b, NewWorkerPool(8, 1024))
+}
+
+func BenchmarkWorkerPoolRing(b *testing.B) {
+	benchmarkWorkerPool(b, NewRingWorkerPool(8, 1024))
+}
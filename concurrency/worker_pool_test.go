@@ -1,6 +1,8 @@
 package concurrency
 
 import (
+	"context"
+	"errors"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -133,3 +135,89 @@ func TestWorkerPoolLargeTaskQueue(t *testing.T) {
 
 	assert.Equal(t, int32(100), counter, "All tasks should be completed even with a large task queue")
 }
+
+// intervalLimiter admits one task per interval.
+type intervalLimiter struct {
+	ticker *time.Ticker
+}
+
+func (l *intervalLimiter) Wait(ctx context.Context) error {
+	select {
+	case <-l.ticker.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestRateLimitedWorkerPool(t *testing.T) {
+	limiter := &intervalLimiter{ticker: time.NewTicker(20 * time.Millisecond)}
+	defer limiter.ticker.Stop()
+	wp := NewRateLimitedWorkerPool(4, 10, limiter)
+
+	var counter int32
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		wp.AddTask(func() {
+			atomic.AddInt32(&counter, 1)
+		})
+	}
+
+	wp.Wait()
+	assert.Equal(t, int32(5), counter, "All tasks should be completed")
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond, "Tasks should start no faster than the limiter allows")
+}
+
+func TestRingWorkerPool(t *testing.T) {
+	wp := NewRingWorkerPool(4, 8)
+	assert.Equal(t, 4, wp.numWorkers, "WorkerPool should have the correct number of workers")
+
+	var counter int32
+	for i := 0; i < 1000; i++ {
+		wp.AddTask(func() {
+			atomic.AddInt32(&counter, 1)
+		})
+	}
+	wp.AddTask(nil)
+
+	wp.Wait()
+	assert.Equal(t, int32(1000), counter, "All tasks added before Wait should be completed")
+}
+
+func TestRingWorkerPoolWaitWithoutTasks(t *testing.T) {
+	wp := NewRingWorkerPool(2, 4)
+	done := make(chan struct{})
+	go func() {
+		wp.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Wait should return promptly when no tasks were added")
+	}
+}
+
+// refusingLimiter rejects every task.
+type refusingLimiter struct{ err error }
+
+func (l refusingLimiter) Wait(context.Context) error { return l.err }
+
+func TestRateLimitedWorkerPoolDropsRefusedTasks(t *testing.T) {
+	errRefused := errors.New("refused")
+	wp := NewRateLimitedWorkerPool(2, 4, refusingLimiter{err: errRefused})
+
+	var counter int32
+	for i := 0; i < 3; i++ {
+		wp.AddTask(func() {
+			atomic.AddInt32(&counter, 1)
+		})
+	}
+	wp.Wait()
+
+	rejected, err := wp.Rejected()
+	assert.Equal(t, int32(0), counter, "Tasks refused by the limiter should not run")
+	assert.Equal(t, uint64(3), rejected, "Rejected should count refused tasks")
+	assert.ErrorIs(t, err, errRefused, "Rejected should report the limiter error")
+}
@@ -0,0 +1,309 @@
+package concurrentmap
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"iter"
+	"math"
+	"reflect"
+	"sync"
+)
+
+const defaultShards = 32
+
+// Hasher maps a key to a hash used to pick its shard.
+type Hasher[K comparable] func(key K) uint64
+
+// ConcurrentMap is a generic map safe for concurrent use. Keys are spread across shards,
+// each guarded by its own RWMutex, so writers to different shards do not contend.
+type ConcurrentMap[K comparable, V any] struct {
+	shards []*shard[K, V]
+	mask   uint64
+	hasher Hasher[K]
+}
+
+// shard is one lock-protected partition of the map.
+type shard[K comparable, V any] struct {
+	mu    sync.RWMutex
+	items map[K]V
+}
+
+// NewConcurrentMap creates an empty ConcurrentMap with the default shard count and hasher.
+func NewConcurrentMap[K comparable, V any]() *ConcurrentMap[K, V] {
+	return NewConcurrentMapWithHasher[K, V](defaultShards, nil)
+}
+
+// NewConcurrentMapWithHasher creates an empty ConcurrentMap with at least the given number of shards,
+// rounded up to a power of two, using hasher to place keys. A nil hasher selects DefaultHasher.
+func NewConcurrentMapWithHasher[K comparable, V any](shards int, hasher Hasher[K]) *ConcurrentMap[K, V] {
+	n := 1
+	for n < shards {
+		n <<= 1
+	}
+	if hasher == nil {
+		hasher = DefaultHasher[K]()
+	}
+
+	m := &ConcurrentMap[K, V]{
+		shards: make([]*shard[K, V], n),
+		mask:   uint64(n - 1),
+		hasher: hasher,
+	}
+	for i := range m.shards {
+		m.shards[i] = &shard[K, V]{items: make(map[K]V)}
+	}
+	return m
+}
+
+// DefaultHasher returns a hasher for any comparable key. Strings and integers take a fast path; every other
+// key type is walked by reflection so that equal keys hash alike (-0 and +0, in any float, complex, struct
+// or array field, land in the same shard) and pointers hash by address.
+func DefaultHasher[K comparable]() Hasher[K] {
+	seed := maphash.MakeSeed()
+	return func(key K) uint64 {
+		switch k := any(key).(type) {
+		case string:
+			return maphash.String(seed, k)
+		case int:
+			return mix(uint64(k))
+		case int64:
+			return mix(uint64(k))
+		case int32:
+			return mix(uint64(k))
+		case uint:
+			return mix(uint64(k))
+		case uint64:
+			return mix(k)
+		case uint32:
+			return mix(uint64(k))
+		case uintptr:
+			return mix(uint64(k))
+		}
+		var h maphash.Hash
+		h.SetSeed(seed)
+		hashValue(&h, reflect.ValueOf(&key).Elem())
+		return h.Sum64()
+	}
+}
+
+// hashValue writes v to h so that values comparing equal with == write the same bytes.
+func hashValue(h *maphash.Hash, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.String:
+		h.WriteString(v.String())
+	case reflect.Bool:
+		if v.Bool() {
+			h.WriteByte(1)
+		} else {
+			h.WriteByte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint64(h, uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint64(h, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeFloat(h, v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		writeFloat(h, real(c))
+		writeFloat(h, imag(c))
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		writeUint64(h, uint64(v.Pointer()))
+	case reflect.Interface:
+		if v.IsNil() {
+			h.WriteByte(0)
+			return
+		}
+		hashValue(h, v.Elem())
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			hashValue(h, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			hashValue(h, v.Field(i))
+		}
+	}
+}
+
+// writeFloat writes f to h, treating -0 and +0 as the same value.
+func writeFloat(h *maphash.Hash, f float64) {
+	if f == 0 {
+		f = 0
+	}
+	writeUint64(h, math.Float64bits(f))
+}
+
+// writeUint64 writes x to h in little-endian order.
+func writeUint64(h *maphash.Hash, x uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], x)
+	h.Write(buf[:])
+}
+
+// mix scrambles the bits of an integer key so that sequential keys spread across shards.
+func mix(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+// shardFor returns the shard owning key.
+func (m *ConcurrentMap[K, V]) shardFor(key K) *shard[K, V] {
+	return m.shards[m.hasher(key)&m.mask]
+}
+
+// Load returns the value stored for key and whether it was present.
+func (m *ConcurrentMap[K, V]) Load(key K) (V, bool) {
+	s := m.shardFor(key)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, ok := s.items[key]
+	return value, ok
+}
+
+// Store sets the value for key.
+func (m *ConcurrentMap[K, V]) Store(key K, value V) {
+	s := m.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items[key] = value
+}
+
+// LoadOrStore returns the existing value for key if present. Otherwise it stores and returns value.
+// The loaded result is true if the value was loaded, false if stored.
+func (m *ConcurrentMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	s := m.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.items[key]; ok {
+		return existing, true
+	}
+	s.items[key] = value
+	return value, false
+}
+
+// GetOrCompute returns the existing value for key if present. Otherwise it stores and returns the result of fn.
+// fn is called at most once per missing key, with the key's shard locked, so it must not access the map.
+func (m *ConcurrentMap[K, V]) GetOrCompute(key K, fn func() V) (value V, computed bool) {
+	s := m.shardFor(key)
+	s.mu.RLock()
+	value, ok := s.items[key]
+	s.mu.RUnlock()
+	if ok {
+		return value, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if value, ok = s.items[key]; ok {
+		return value, false
+	}
+	value = fn()
+	s.items[key] = value
+	return value, true
+}
+
+// Compute atomically updates the entry for key. fn receives the current value and whether it exists, and
+// returns the new value and whether to keep it; returning false deletes the entry.
+// fn runs with the key's shard locked, so it must not access the map.
+func (m *ConcurrentMap[K, V]) Compute(key K, fn func(old V, exists bool) (V, bool)) (V, bool) {
+	s := m.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, exists := s.items[key]
+	value, keep := fn(old, exists)
+	if keep {
+		s.items[key] = value
+	} else {
+		delete(s.items, key)
+	}
+	return value, keep
+}
+
+// Delete removes key from the map.
+func (m *ConcurrentMap[K, V]) Delete(key K) {
+	s := m.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.items, key)
+}
+
+// LoadAndDelete removes key from the map, returning the previous value if any.
+func (m *ConcurrentMap[K, V]) LoadAndDelete(key K) (V, bool) {
+	s := m.shardFor(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.items[key]
+	delete(s.items, key)
+	return value, ok
+}
+
+// Len returns the number of entries. Concurrent writes may make the result stale immediately.
+func (m *ConcurrentMap[K, V]) Len() int {
+	n := 0
+	for _, s := range m.shards {
+		s.mu.RLock()
+		n += len(s.items)
+		s.mu.RUnlock()
+	}
+	return n
+}
+
+// Range calls fn for each entry until fn returns false. Each shard is copied under its read lock
+// and fn is called without holding any lock, so fn may modify the map.
+func (m *ConcurrentMap[K, V]) Range(fn func(key K, value V) bool) {
+	for key, value := range m.All() {
+		if !fn(key, value) {
+			return
+		}
+	}
+}
+
+// All returns an iterator over the entries of the map with the same consistency as Range.
+func (m *ConcurrentMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		type entry struct {
+			key   K
+			value V
+		}
+		var entries []entry
+		for _, s := range m.shards {
+			s.mu.RLock()
+			entries = entries[:0]
+			for k, v := range s.items {
+				entries = append(entries, entry{k, v})
+			}
+			s.mu.RUnlock()
+
+			for _, e := range entries {
+				if !yield(e.key, e.value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Keys returns an iterator over the keys of the map.
+func (m *ConcurrentMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range m.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// Clear removes every entry.
+func (m *ConcurrentMap[K, V]) Clear() {
+	for _, s := range m.shards {
+		s.mu.Lock()
+		clear(s.items)
+		s.mu.Unlock()
+	}
+}
@@ -0,0 +1,166 @@
+package concurrentmap
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+const benchmarkSize = 10000 // Number of distinct keys for benchmarks
+
+// mutexMap is a plain map guarded by a single RWMutex, used as a baseline.
+type mutexMap struct {
+	mu    sync.RWMutex
+	items map[int]int
+}
+
+func (m *mutexMap) Load(key int) (int, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	v, ok := m.items[key]
+	return v, ok
+}
+
+func (m *mutexMap) Store(key, value int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.items[key] = value
+}
+
+func BenchmarkConcurrentMapStore(b *testing.B) {
+	m := NewConcurrentMap[int, int]()
+	b.RunParallel(func(pb *testing.PB) {
+		i := rand.Int()
+		for pb.Next() {
+			m.Store(i%benchmarkSize, i)
+			i++
+		}
+	})
+}
+
+func BenchmarkSyncMapStore(b *testing.B) {
+	var m sync.Map
+	b.RunParallel(func(pb *testing.PB) {
+		i := rand.Int()
+		for pb.Next() {
+			m.Store(i%benchmarkSize, i)
+			i++
+		}
+	})
+}
+
+func BenchmarkMutexMapStore(b *testing.B) {
+	m := &mutexMap{items: make(map[int]int)}
+	b.RunParallel(func(pb *testing.PB) {
+		i := rand.Int()
+		for pb.Next() {
+			m.Store(i%benchmarkSize, i)
+			i++
+		}
+	})
+}
+
+func BenchmarkConcurrentMapLoad(b *testing.B) {
+	m := NewConcurrentMap[int, int]()
+	for i := 0; i < benchmarkSize; i++ {
+		m.Store(i, i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := rand.Int()
+		for pb.Next() {
+			m.Load(i % benchmarkSize)
+			i++
+		}
+	})
+}
+
+func BenchmarkSyncMapLoad(b *testing.B) {
+	var m sync.Map
+	for i := 0; i < benchmarkSize; i++ {
+		m.Store(i, i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := rand.Int()
+		for pb.Next() {
+			m.Load(i % benchmarkSize)
+			i++
+		}
+	})
+}
+
+func BenchmarkMutexMapLoad(b *testing.B) {
+	m := &mutexMap{items: make(map[int]int)}
+	for i := 0; i < benchmarkSize; i++ {
+		m.Store(i, i)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := rand.Int()
+		for pb.Next() {
+			m.Load(i % benchmarkSize)
+			i++
+		}
+	})
+}
+
+func BenchmarkConcurrentMapMixed(b *testing.B) {
+	m := NewConcurrentMap[int, int]()
+	b.RunParallel(func(pb *testing.PB) {
+		i := rand.Int()
+		for pb.Next() {
+			if i%4 == 0 {
+				m.Load(i % benchmarkSize)
+			} else {
+				m.Store(i%benchmarkSize, i)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkSyncMapMixed(b *testing.B) {
+	var m sync.Map
+	b.RunParallel(func(pb *testing.PB) {
+		i := rand.Int()
+		for pb.Next() {
+			if i%4 == 0 {
+				m.Load(i % benchmarkSize)
+			} else {
+				m.Store(i%benchmarkSize, i)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkMutexMapMixed(b *testing.B) {
+	m := &mutexMap{items: make(map[int]int)}
+	b.RunParallel(func(pb *testing.PB) {
+		i := rand.Int()
+		for pb.Next() {
+			if i%4 == 0 {
+				m.Load(i % benchmarkSize)
+			} else {
+				m.Store(i%benchmarkSize, i)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkConcurrentMapCompute(b *testing.B) {
+	m := NewConcurrentMap[int, int]()
+	increment := func(old int, _ bool) (int, bool) { return old + 1, true }
+	b.RunParallel(func(pb *testing.PB) {
+		i := rand.Int()
+		for pb.Next() {
+			m.Compute(i%benchmarkSize, increment)
+			i++
+		}
+	})
+}
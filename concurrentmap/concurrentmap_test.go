@@ -0,0 +1,225 @@
+package concurrentmap
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrentMapStoreLoadDelete(t *testing.T) {
+	m := NewConcurrentMap[string, int]()
+
+	m.Store("a", 1)
+	m.Store("b", 2)
+	value, ok := m.Load("a")
+	assert.True(t, ok, "Load should find a stored key")
+	assert.Equal(t, 1, value)
+	assert.Equal(t, 2, m.Len())
+
+	m.Delete("a")
+	_, ok = m.Load("a")
+	assert.False(t, ok, "Load should not find a deleted key")
+
+	value, ok = m.LoadAndDelete("b")
+	assert.True(t, ok)
+	assert.Equal(t, 2, value, "LoadAndDelete should return the removed value")
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestConcurrentMapLoadOrStore(t *testing.T) {
+	m := NewConcurrentMap[int, string]()
+
+	actual, loaded := m.LoadOrStore(1, "first")
+	assert.False(t, loaded, "First LoadOrStore should store")
+	assert.Equal(t, "first", actual)
+
+	actual, loaded = m.LoadOrStore(1, "second")
+	assert.True(t, loaded, "Second LoadOrStore should load")
+	assert.Equal(t, "first", actual, "Existing value should be kept")
+}
+
+func TestConcurrentMapGetOrCompute(t *testing.T) {
+	m := NewConcurrentMap[int, int]()
+	calls := 0
+	fn := func() int { calls++; return 42 }
+
+	value, computed := m.GetOrCompute(1, fn)
+	assert.True(t, computed)
+	assert.Equal(t, 42, value)
+
+	value, computed = m.GetOrCompute(1, fn)
+	assert.False(t, computed, "Existing value should not be recomputed")
+	assert.Equal(t, 42, value)
+	assert.Equal(t, 1, calls)
+}
+
+func TestConcurrentMapCompute(t *testing.T) {
+	m := NewConcurrentMap[string, int]()
+	increment := func(old int, _ bool) (int, bool) { return old + 1, true }
+
+	m.Compute("counter", increment)
+	value, kept := m.Compute("counter", increment)
+	assert.True(t, kept)
+	assert.Equal(t, 2, value, "Compute should see the previous value")
+
+	_, kept = m.Compute("counter", func(int, bool) (int, bool) { return 0, false })
+	assert.False(t, kept)
+	_, ok := m.Load("counter")
+	assert.False(t, ok, "Compute returning false should delete the entry")
+}
+
+func TestConcurrentMapRangeAndIterators(t *testing.T) {
+	m := NewConcurrentMap[int, int]()
+	for i := 0; i < 100; i++ {
+		m.Store(i, i*i)
+	}
+
+	seen := map[int]int{}
+	m.Range(func(k, v int) bool {
+		seen[k] = v
+		return true
+	})
+	assert.Equal(t, 100, len(seen), "Range should visit every entry")
+	assert.Equal(t, 81, seen[9])
+
+	count := 0
+	m.Range(func(int, int) bool {
+		count++
+		return count < 10
+	})
+	assert.Equal(t, 10, count, "Range should stop when fn returns false")
+
+	var keys []int
+	for k := range m.Keys() {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	assert.Equal(t, 100, len(keys))
+	assert.Equal(t, 0, keys[0])
+
+	for k := range m.All() {
+		m.Delete(k) // Modifying the map while iterating must not deadlock
+	}
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestConcurrentMapCustomHasher(t *testing.T) {
+	type point struct{ X, Y int }
+	m := NewConcurrentMapWithHasher[point, string](3, func(p point) uint64 { return uint64(p.X*31 + p.Y) })
+	assert.Equal(t, 4, len(m.shards), "Shard count should be rounded up to a power of two")
+
+	m.Store(point{1, 2}, "a")
+	value, ok := m.Load(point{1, 2})
+	assert.True(t, ok)
+	assert.Equal(t, "a", value)
+}
+
+func TestDefaultHasherStable(t *testing.T) {
+	type key struct {
+		Name string
+		ID   int
+	}
+	h := DefaultHasher[key]()
+	assert.Equal(t, h(key{"a", 1}), h(key{"a", 1}), "Equal struct keys should hash the same")
+
+	type node struct{ V int }
+	ph := DefaultHasher[*node]()
+	n := &node{V: 1}
+	before := ph(n)
+	n.V = 2
+	assert.Equal(t, before, ph(n), "Pointer keys should hash by address")
+
+	fh := DefaultHasher[float64]()
+	assert.Equal(t, fh(0), fh(math.Copysign(0, -1)), "Positive and negative zero should hash the same")
+
+	f32h := DefaultHasher[float32]()
+	assert.Equal(t, f32h(0), f32h(float32(math.Copysign(0, -1))), "float32 zeros should hash the same")
+
+	ch := DefaultHasher[complex128]()
+	assert.Equal(t, ch(0), ch(complex(math.Copysign(0, -1), 0)), "complex zeros should hash the same")
+
+	m := NewConcurrentMap[any, int]()
+	m.Store(n, 1)
+	m.Store("x", 2)
+	value, ok := m.Load(n)
+	assert.True(t, ok)
+	assert.Equal(t, 1, value, "Interface keys should be supported")
+}
+
+func TestConcurrentMapSignedZeroKeys(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+
+	type key struct{ F float64 }
+	for i := 0; i < 100; i++ {
+		m := NewConcurrentMap[key, int]()
+		m.Store(key{0}, 1)
+		m.Store(key{negZero}, 2)
+		assert.Equal(t, 1, m.Len(), "A struct key with -0 should replace the one with +0")
+
+		f32 := NewConcurrentMap[float32, int]()
+		f32.Store(0, 1)
+		f32.Store(float32(negZero), 2)
+		assert.Equal(t, 1, f32.Len(), "A float32 -0 key should replace +0")
+
+		arr := NewConcurrentMap[[2]float32, int]()
+		arr.Store([2]float32{0, 1}, 1)
+		arr.Store([2]float32{float32(negZero), 1}, 2)
+		assert.Equal(t, 1, arr.Len(), "An array key with -0 should replace the one with +0")
+
+		iface := NewConcurrentMap[any, int]()
+		iface.Store(key{0}, 1)
+		iface.Store(key{negZero}, 2)
+		assert.Equal(t, 1, iface.Len(), "An interface key holding -0 should replace the one holding +0")
+	}
+}
+
+func TestDefaultHasherUnexportedFields(t *testing.T) {
+	type inner struct {
+		p *int
+		c complex64
+	}
+	type key struct {
+		name  string
+		inner inner
+		any   any
+	}
+	x := 1
+	h := DefaultHasher[key]()
+	a := key{name: "a", inner: inner{p: &x, c: complex(0, 1)}, any: 7}
+	b := key{name: "a", inner: inner{p: &x, c: complex(float32(math.Copysign(0, -1)), 1)}, any: 7}
+	assert.Equal(t, h(a), h(b), "Equal keys with unexported fields should hash the same")
+}
+
+func TestConcurrentMapConcurrentAccess(t *testing.T) {
+	m := NewConcurrentMap[int, int]()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				m.Compute(i%10, func(old int, _ bool) (int, bool) { return old + 1, true })
+				m.Load(i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	total := 0
+	m.Range(func(_, v int) bool {
+		total += v
+		return true
+	})
+	assert.Equal(t, 8000, total, "Concurrent Compute calls should not lose updates")
+}
+
+func TestConcurrentMapClear(t *testing.T) {
+	m := NewConcurrentMap[int, int]()
+	m.Store(1, 1)
+	m.Store(2, 2)
+	m.Clear()
+	assert.Equal(t, 0, m.Len(), "Clear should remove every entry")
+}
@@ -0,0 +1,255 @@
+package eventbus
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/vd09/go-generic-utils/concurrency"
+)
+
+// OverflowPolicy decides what happens when an event is published to a subscriber whose buffer is full.
+type OverflowPolicy int
+
+const (
+	Block      OverflowPolicy = iota // Publish waits until the subscriber has room
+	DropOldest                       // The oldest buffered event is discarded to make room
+	DropNewest                       // The new event is discarded
+)
+
+// Handler receives events published on topics matching a subscription.
+type Handler[T any] func(topic string, event T)
+
+// BusOptions configures how a Bus delivers events.
+type BusOptions struct {
+	// Pool runs handler invocations instead of dedicated goroutines when set.
+	Pool *concurrency.WorkerPool
+	// Ordered keeps events in publish order for each subscriber when Pool is set.
+	// Without a Pool, delivery is always ordered.
+	Ordered bool
+}
+
+// SubscribeOptions configures a single subscription.
+type SubscribeOptions struct {
+	BufferSize int            // Number of events buffered for the subscriber (default 64)
+	Overflow   OverflowPolicy // Policy applied when the buffer is full
+}
+
+// Bus is an in-process publish/subscribe event bus for events of type T.
+// Topics are dot-separated; subscription patterns may use "*" to match exactly one segment
+// and a trailing ">" to match one or more remaining segments.
+type Bus[T any] struct {
+	mu      sync.RWMutex
+	options BusOptions
+	subs    map[uint64]*Subscription[T]
+	nextID  uint64
+	closed  bool
+}
+
+// NewBus creates a Bus that delivers events to each subscriber on its own goroutine.
+func NewBus[T any]() *Bus[T] {
+	return NewBusWithOptions[T](BusOptions{})
+}
+
+// NewBusWithOptions creates a Bus with the given delivery options.
+func NewBusWithOptions[T any](options BusOptions) *Bus[T] {
+	return &Bus[T]{
+		options: options,
+		subs:    make(map[uint64]*Subscription[T]),
+	}
+}
+
+// Subscribe registers handler for topics matching pattern with default options.
+func (b *Bus[T]) Subscribe(pattern string, handler Handler[T]) *Subscription[T] {
+	return b.SubscribeWithOptions(pattern, handler, SubscribeOptions{})
+}
+
+// SubscribeWithOptions registers handler for topics matching pattern.
+// It returns nil if the bus has been closed.
+func (b *Bus[T]) SubscribeWithOptions(pattern string, handler Handler[T], options SubscribeOptions) *Subscription[T] {
+	if options.BufferSize <= 0 {
+		options.BufferSize = 64
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil
+	}
+
+	b.nextID++
+	sub := &Subscription[T]{
+		bus:      b,
+		id:       b.nextID,
+		pattern:  strings.Split(pattern, "."),
+		handler:  handler,
+		capacity: options.BufferSize,
+		overflow: options.Overflow,
+	}
+	sub.cond = sync.NewCond(&sub.mu)
+	b.subs[sub.id] = sub
+	return sub
+}
+
+// Publish delivers event to every subscriber whose pattern matches topic.
+// With the Block policy it waits while a matching subscriber's buffer is full.
+func (b *Bus[T]) Publish(topic string, event T) {
+	segments := strings.Split(topic, ".")
+
+	b.mu.RLock()
+	matched := make([]*Subscription[T], 0, len(b.subs))
+	for _, sub := range b.subs {
+		if match(sub.pattern, segments) {
+			matched = append(matched, sub)
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, sub := range matched {
+		sub.enqueue(envelope[T]{topic: topic, event: event})
+	}
+}
+
+// Close unsubscribes every subscriber. Later publishes are ignored and later subscriptions return nil.
+func (b *Bus[T]) Close() {
+	b.mu.Lock()
+	subs := b.subs
+	b.subs = make(map[uint64]*Subscription[T])
+	b.closed = true
+	b.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.close()
+	}
+}
+
+// Len returns the number of active subscriptions.
+func (b *Bus[T]) Len() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subs)
+}
+
+// match reports whether topic segments match pattern segments.
+func match(pattern, topic []string) bool {
+	for i, p := range pattern {
+		if p == ">" && i == len(pattern)-1 {
+			return len(topic) > i
+		}
+		if i >= len(topic) || (p != "*" && p != topic[i]) {
+			return false
+		}
+	}
+	return len(pattern) == len(topic)
+}
+
+// envelope is a buffered event together with its topic.
+type envelope[T any] struct {
+	topic string
+	event T
+}
+
+// Subscription is a registered handler with its own delivery buffer.
+type Subscription[T any] struct {
+	bus      *Bus[T]
+	id       uint64
+	pattern  []string
+	handler  Handler[T]
+	capacity int
+	overflow OverflowPolicy
+
+	mu       sync.Mutex
+	cond     *sync.Cond    // Signalled when buffer space frees up or the subscription closes
+	buffer   []envelope[T] // Pending events, oldest first
+	draining bool          // Whether a drain is scheduled or running
+	closed   bool
+	dropped  atomic.Uint64
+}
+
+// Unsubscribe stops delivery to the subscription and discards its pending events.
+// A handler invocation already in progress is allowed to finish.
+func (s *Subscription[T]) Unsubscribe() {
+	s.bus.mu.Lock()
+	delete(s.bus.subs, s.id)
+	s.bus.mu.Unlock()
+	s.close()
+}
+
+// Dropped returns the number of events discarded because the buffer was full.
+func (s *Subscription[T]) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Pending returns the number of buffered events awaiting delivery.
+func (s *Subscription[T]) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.buffer)
+}
+
+// close discards pending events and wakes publishers blocked on the subscription.
+func (s *Subscription[T]) close() {
+	s.mu.Lock()
+	s.closed = true
+	s.buffer = nil
+	s.mu.Unlock()
+	s.cond.Broadcast()
+}
+
+// enqueue buffers an event according to the overflow policy and schedules delivery.
+func (s *Subscription[T]) enqueue(env envelope[T]) {
+	s.mu.Lock()
+	for !s.closed && len(s.buffer) >= s.capacity && s.overflow == Block {
+		s.cond.Wait()
+	}
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	if len(s.buffer) >= s.capacity {
+		s.dropped.Add(1)
+		if s.overflow == DropNewest {
+			s.mu.Unlock()
+			return
+		}
+		s.buffer = s.buffer[1:]
+	}
+	s.buffer = append(s.buffer, env)
+
+	schedule := !s.draining
+	s.draining = true
+	s.mu.Unlock()
+
+	if schedule {
+		// An ordered drain occupies a worker; an unordered one only feeds the pool, so it gets its own goroutine.
+		if pool := s.bus.options.Pool; pool != nil && s.bus.options.Ordered {
+			pool.AddTask(s.drain)
+		} else {
+			go s.drain()
+		}
+	}
+}
+
+// drain delivers buffered events until the buffer is empty.
+// Without ordering, each event is handed to the pool as a separate task.
+func (s *Subscription[T]) drain() {
+	pool := s.bus.options.Pool
+	for {
+		s.mu.Lock()
+		if s.closed || len(s.buffer) == 0 {
+			s.draining = false
+			s.mu.Unlock()
+			return
+		}
+		env := s.buffer[0]
+		s.buffer = s.buffer[1:]
+		s.mu.Unlock()
+		s.cond.Signal()
+
+		if pool != nil && !s.bus.options.Ordered {
+			pool.AddTask(func() { s.handler(env.topic, env.event) })
+		} else {
+			s.handler(env.topic, env.event)
+		}
+	}
+}
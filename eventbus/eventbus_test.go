@@ -0,0 +1,225 @@
+package eventbus
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vd09/go-generic-utils/concurrency"
+)
+
+// collector records delivered events in order.
+type collector struct {
+	mu     sync.Mutex
+	events []int
+}
+
+func (c *collector) handle(_ string, event int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.events = append(c.events, event)
+}
+
+func (c *collector) get() []int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]int(nil), c.events...)
+}
+
+func TestMatch(t *testing.T) {
+	cases := []struct {
+		pattern, topic string
+		want           bool
+	}{
+		{"orders.created", "orders.created", true},
+		{"orders.created", "orders.deleted", false},
+		{"orders.*", "orders.created", true},
+		{"orders.*", "orders.created.eu", false},
+		{"*.created", "users.created", true},
+		{"orders.>", "orders.created.eu", true},
+		{"orders.>", "orders", false},
+		{">", "anything.at.all", true},
+		{"orders", "orders.created", false},
+	}
+	for _, c := range cases {
+		got := match(strings.Split(c.pattern, "."), strings.Split(c.topic, "."))
+		assert.Equal(t, c.want, got, "match(%q, %q)", c.pattern, c.topic)
+	}
+}
+
+func TestBusPublishSubscribe(t *testing.T) {
+	bus := NewBus[int]()
+	var exact, wildcard collector
+	bus.Subscribe("orders.created", exact.handle)
+	bus.Subscribe("orders.*", wildcard.handle)
+
+	bus.Publish("orders.created", 1)
+	bus.Publish("orders.deleted", 2)
+	bus.Publish("users.created", 3)
+
+	assert.Eventually(t, func() bool { return len(wildcard.get()) == 2 }, time.Second, time.Millisecond)
+	assert.Equal(t, []int{1}, exact.get(), "Exact subscriber should only receive its topic")
+	assert.Equal(t, []int{1, 2}, wildcard.get(), "Wildcard subscriber should receive matching topics in order")
+}
+
+func TestBusUnsubscribe(t *testing.T) {
+	bus := NewBus[int]()
+	var c collector
+	sub := bus.Subscribe("a", c.handle)
+
+	bus.Publish("a", 1)
+	assert.Eventually(t, func() bool { return len(c.get()) == 1 }, time.Second, time.Millisecond)
+
+	sub.Unsubscribe()
+	bus.Publish("a", 2)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, []int{1}, c.get(), "Unsubscribed handler should not receive events")
+	assert.Equal(t, 0, bus.Len())
+}
+
+func TestBusDropNewest(t *testing.T) {
+	bus := NewBus[int]()
+	release := make(chan struct{})
+	var c collector
+	sub := bus.SubscribeWithOptions("a", func(topic string, event int) {
+		<-release
+		c.handle(topic, event)
+	}, SubscribeOptions{BufferSize: 2, Overflow: DropNewest})
+
+	bus.Publish("a", 1)
+	assert.Eventually(t, func() bool { return sub.Pending() == 0 }, time.Second, time.Millisecond)
+	for i := 2; i <= 5; i++ {
+		bus.Publish("a", i)
+	}
+	close(release)
+
+	assert.Eventually(t, func() bool { return len(c.get()) == 3 }, time.Second, time.Millisecond)
+	assert.Equal(t, []int{1, 2, 3}, c.get(), "Newest events should be dropped when the buffer is full")
+	assert.Equal(t, uint64(2), sub.Dropped())
+}
+
+func TestBusDropOldest(t *testing.T) {
+	bus := NewBus[int]()
+	release := make(chan struct{})
+	var c collector
+	sub := bus.SubscribeWithOptions("a", func(topic string, event int) {
+		<-release
+		c.handle(topic, event)
+	}, SubscribeOptions{BufferSize: 2, Overflow: DropOldest})
+
+	bus.Publish("a", 1)
+	assert.Eventually(t, func() bool { return sub.Pending() == 0 }, time.Second, time.Millisecond)
+	for i := 2; i <= 5; i++ {
+		bus.Publish("a", i)
+	}
+	close(release)
+
+	assert.Eventually(t, func() bool { return len(c.get()) == 3 }, time.Second, time.Millisecond)
+	assert.Equal(t, []int{1, 4, 5}, c.get(), "Oldest events should be dropped when the buffer is full")
+	assert.Equal(t, uint64(2), sub.Dropped())
+}
+
+func TestBusBlock(t *testing.T) {
+	bus := NewBus[int]()
+	release := make(chan struct{})
+	var c collector
+	sub := bus.SubscribeWithOptions("a", func(topic string, event int) {
+		<-release
+		c.handle(topic, event)
+	}, SubscribeOptions{BufferSize: 1, Overflow: Block})
+
+	bus.Publish("a", 1)
+	assert.Eventually(t, func() bool { return sub.Pending() == 0 }, time.Second, time.Millisecond)
+	bus.Publish("a", 2)
+
+	published := make(chan struct{})
+	go func() {
+		bus.Publish("a", 3)
+		close(published)
+	}()
+	select {
+	case <-published:
+		t.Fatal("Publish should block while the buffer is full")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	<-published
+	assert.Eventually(t, func() bool { return len(c.get()) == 3 }, time.Second, time.Millisecond)
+	assert.Equal(t, []int{1, 2, 3}, c.get(), "Blocking policy should deliver every event")
+}
+
+func TestBusUnsubscribeWakesBlockedPublisher(t *testing.T) {
+	bus := NewBus[int]()
+	release := make(chan struct{})
+	defer close(release)
+	sub := bus.SubscribeWithOptions("a", func(string, int) { <-release }, SubscribeOptions{BufferSize: 1})
+
+	bus.Publish("a", 1)
+	assert.Eventually(t, func() bool { return sub.Pending() == 0 }, time.Second, time.Millisecond)
+	bus.Publish("a", 2)
+
+	published := make(chan struct{})
+	go func() {
+		bus.Publish("a", 3)
+		close(published)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	sub.Unsubscribe()
+
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("Unsubscribe should wake blocked publishers")
+	}
+}
+
+func TestBusOrderedPool(t *testing.T) {
+	pool := concurrency.NewWorkerPool(4, 16)
+	bus := NewBusWithOptions[int](BusOptions{Pool: pool, Ordered: true})
+	var a, b collector
+	bus.Subscribe("a", a.handle)
+	bus.Subscribe("a", b.handle)
+
+	for i := 0; i < 100; i++ {
+		bus.Publish("a", i)
+	}
+	assert.Eventually(t, func() bool { return len(a.get()) == 100 && len(b.get()) == 100 }, time.Second, time.Millisecond)
+	bus.Close()
+	pool.Wait()
+
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, i, a.get()[i], "Ordered pool delivery should keep publish order")
+		assert.Equal(t, i, b.get()[i], "Ordered pool delivery should keep publish order")
+	}
+}
+
+func TestBusUnorderedPool(t *testing.T) {
+	pool := concurrency.NewWorkerPool(4, 16)
+	bus := NewBusWithOptions[int](BusOptions{Pool: pool})
+	var count int32
+	bus.Subscribe("a", func(string, int) { atomic.AddInt32(&count, 1) })
+
+	for i := 0; i < 100; i++ {
+		bus.Publish("a", i)
+	}
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&count) == 100 }, time.Second, time.Millisecond)
+	bus.Close()
+	pool.Wait()
+}
+
+func TestBusClose(t *testing.T) {
+	bus := NewBus[int]()
+	var c collector
+	bus.Subscribe("a", c.handle)
+
+	bus.Close()
+	bus.Publish("a", 1)
+	time.Sleep(10 * time.Millisecond)
+
+	assert.Empty(t, c.get(), "Closed bus should not deliver events")
+	assert.Nil(t, bus.Subscribe("a", c.handle), "Closed bus should reject subscriptions")
+}
@@ -0,0 +1,140 @@
+package memoize
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/vd09/go-generic-utils/singleflight"
+)
+
+// Options configures a Memoizer. Zero values disable the corresponding limit.
+type Options struct {
+	TTL        time.Duration // How long a successful result is cached; 0 caches until evicted
+	ErrorTTL   time.Duration // How long an error is cached; 0 does not cache errors
+	MaxEntries int           // Maximum number of cached results; the least recently used is evicted first
+}
+
+// entry is a cached result.
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	err       error
+	expiresAt time.Time // Zero when the entry never expires
+}
+
+// Memoizer caches the results of a function per key. Concurrent calls for a key that is not cached
+// are merged into a single call of the function.
+type Memoizer[K comparable, V any] struct {
+	fn      func(ctx context.Context, key K) (V, error)
+	options Options
+	flight  *singleflight.Group[K, V]
+
+	mu      sync.Mutex
+	entries map[K]*list.Element // Values are *entry[K, V]
+	lru     *list.List          // Most recently used at the front
+	now     func() time.Time
+}
+
+// Memoize creates a Memoizer for fn. fn receives a context that is never cancelled, since its result
+// is shared by every caller waiting for the key.
+func Memoize[K comparable, V any](fn func(ctx context.Context, key K) (V, error), options Options) *Memoizer[K, V] {
+	return &Memoizer[K, V]{
+		fn:      fn,
+		options: options,
+		flight:  singleflight.NewGroup[K, V](0),
+		entries: make(map[K]*list.Element),
+		lru:     list.New(),
+		now:     time.Now,
+	}
+}
+
+// Get returns the cached result for key, calling the function if there is none.
+// If ctx is done before the result is available, Get returns ctx.Err() but the call keeps running.
+func (m *Memoizer[K, V]) Get(ctx context.Context, key K) (V, error) {
+	if e, ok := m.lookup(key); ok {
+		return e.value, e.err
+	}
+	return m.flight.Do(ctx, key, func() (V, error) {
+		if e, ok := m.lookup(key); ok {
+			return e.value, e.err
+		}
+		value, err := m.fn(context.Background(), key)
+		m.store(key, value, err)
+		return value, err
+	})
+}
+
+// Forget removes the cached result for key.
+func (m *Memoizer[K, V]) Forget(key K) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if el, ok := m.entries[key]; ok {
+		m.removeLocked(el)
+	}
+}
+
+// Purge removes every cached result.
+func (m *Memoizer[K, V]) Purge() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = make(map[K]*list.Element)
+	m.lru.Init()
+}
+
+// Len returns the number of cached results, including expired ones not yet removed.
+func (m *Memoizer[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lru.Len()
+}
+
+// lookup returns the unexpired cached entry for key and marks it recently used.
+func (m *Memoizer[K, V]) lookup(key K) (*entry[K, V], bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	el, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*entry[K, V])
+	if !e.expiresAt.IsZero() && !m.now().Before(e.expiresAt) {
+		m.removeLocked(el)
+		return nil, false
+	}
+	m.lru.MoveToFront(el)
+	return e, true
+}
+
+// store caches a result according to the options, evicting the least recently used entry if needed.
+func (m *Memoizer[K, V]) store(key K, value V, err error) {
+	ttl := m.options.TTL
+	if err != nil {
+		if m.options.ErrorTTL <= 0 {
+			return
+		}
+		ttl = m.options.ErrorTTL
+	}
+
+	e := &entry[K, V]{key: key, value: value, err: err}
+	if ttl > 0 {
+		e.expiresAt = m.now().Add(ttl)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if el, ok := m.entries[key]; ok {
+		m.removeLocked(el)
+	}
+	m.entries[key] = m.lru.PushFront(e)
+	if m.options.MaxEntries > 0 && m.lru.Len() > m.options.MaxEntries {
+		m.removeLocked(m.lru.Back())
+	}
+}
+
+// removeLocked deletes the entry held by el.
+func (m *Memoizer[K, V]) removeLocked(el *list.Element) {
+	e := m.lru.Remove(el).(*entry[K, V])
+	delete(m.entries, e.key)
+}
@@ -0,0 +1,28 @@
+package memoize
+
+import (
+	"context"
+	"testing"
+)
+
+func BenchmarkMemoizeGetHit(b *testing.B) {
+	m := Memoize(func(_ context.Context, key int) (int, error) { return key, nil }, Options{})
+	ctx := context.Background()
+	for i := 0; i < 100; i++ {
+		_, _ = m.Get(ctx, i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = m.Get(ctx, i%100)
+	}
+}
+
+func BenchmarkOnceValue(b *testing.B) {
+	get := OnceValue(func() (int, error) { return 1, nil })
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = get()
+	}
+}
@@ -0,0 +1,138 @@
+package memoize
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// counter is a memoized test function that counts its calls per key.
+type counter struct {
+	mu    sync.Mutex
+	calls map[int]int
+	err   error
+}
+
+func (c *counter) fn(_ context.Context, key int) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.calls == nil {
+		c.calls = map[int]int{}
+	}
+	c.calls[key]++
+	return key * 10, c.err
+}
+
+func (c *counter) count(key int) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.calls[key]
+}
+
+func TestMemoizeCaches(t *testing.T) {
+	var c counter
+	m := Memoize(c.fn, Options{})
+	ctx := context.Background()
+
+	v, err := m.Get(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, v)
+	_, _ = m.Get(ctx, 1)
+	assert.Equal(t, 1, c.count(1), "Result should be cached")
+
+	m.Forget(1)
+	_, _ = m.Get(ctx, 1)
+	assert.Equal(t, 2, c.count(1), "Forget should drop the cached result")
+
+	m.Purge()
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestMemoizeTTL(t *testing.T) {
+	var c counter
+	now := time.Unix(0, 0)
+	m := Memoize(c.fn, Options{TTL: time.Minute})
+	m.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	_, _ = m.Get(ctx, 1)
+	now = now.Add(30 * time.Second)
+	_, _ = m.Get(ctx, 1)
+	assert.Equal(t, 1, c.count(1), "Result should be cached within the TTL")
+
+	now = now.Add(30 * time.Second)
+	_, _ = m.Get(ctx, 1)
+	assert.Equal(t, 2, c.count(1), "Result should expire after the TTL")
+}
+
+func TestMemoizeErrorPolicy(t *testing.T) {
+	errBoom := errors.New("boom")
+	ctx := context.Background()
+
+	uncached := &counter{err: errBoom}
+	m := Memoize(uncached.fn, Options{})
+	_, err := m.Get(ctx, 1)
+	assert.ErrorIs(t, err, errBoom)
+	_, _ = m.Get(ctx, 1)
+	assert.Equal(t, 2, uncached.count(1), "Errors should not be cached by default")
+
+	now := time.Unix(0, 0)
+	cached := &counter{err: errBoom}
+	m = Memoize(cached.fn, Options{ErrorTTL: time.Second})
+	m.now = func() time.Time { return now }
+	_, _ = m.Get(ctx, 1)
+	_, err = m.Get(ctx, 1)
+	assert.ErrorIs(t, err, errBoom, "Cached error should be returned")
+	assert.Equal(t, 1, cached.count(1), "Errors should be cached for ErrorTTL")
+
+	now = now.Add(time.Second)
+	_, _ = m.Get(ctx, 1)
+	assert.Equal(t, 2, cached.count(1), "Cached error should expire after ErrorTTL")
+}
+
+func TestMemoizeMaxEntries(t *testing.T) {
+	var c counter
+	m := Memoize(c.fn, Options{MaxEntries: 2})
+	ctx := context.Background()
+
+	_, _ = m.Get(ctx, 1)
+	_, _ = m.Get(ctx, 2)
+	_, _ = m.Get(ctx, 1) // 2 is now least recently used
+	_, _ = m.Get(ctx, 3)
+	assert.Equal(t, 2, m.Len(), "Cache should respect MaxEntries")
+
+	_, _ = m.Get(ctx, 1)
+	assert.Equal(t, 1, c.count(1), "Recently used entry should be kept")
+	_, _ = m.Get(ctx, 2)
+	assert.Equal(t, 2, c.count(2), "Least recently used entry should be evicted")
+}
+
+func TestMemoizeDeduplicatesConcurrentCalls(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	m := Memoize(func(_ context.Context, key string) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return len(key), nil
+	}, Options{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, _ := m.Get(context.Background(), "abc")
+			assert.Equal(t, 3, v)
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls, "Concurrent first calls should be merged")
+}
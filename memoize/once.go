@@ -0,0 +1,44 @@
+package memoize
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/vd09/go-generic-utils/singleflight"
+)
+
+// OnceValue returns a function that calls fn and caches its value after the first success.
+// Unlike sync.OnceValues, an error is not cached: the next call tries again. Concurrent calls
+// while an attempt is in flight share that attempt's result.
+func OnceValue[T any](fn func() (T, error)) func() (T, error) {
+	get := OnceValueContext(func(context.Context) (T, error) { return fn() })
+	return func() (T, error) {
+		return get(context.Background())
+	}
+}
+
+// OnceValueContext is like OnceValue, but each caller may stop waiting for an in-flight attempt when its ctx is done.
+// The attempt itself keeps running and its value is still cached on success. fn receives a context that is never cancelled.
+func OnceValueContext[T any](fn func(ctx context.Context) (T, error)) func(ctx context.Context) (T, error) {
+	var (
+		done   atomic.Bool
+		value  T
+		flight = singleflight.NewGroup[struct{}, T](0)
+	)
+	return func(ctx context.Context) (T, error) {
+		if done.Load() {
+			return value, nil
+		}
+		return flight.Do(ctx, struct{}{}, func() (T, error) {
+			if done.Load() {
+				return value, nil
+			}
+			v, err := fn(context.Background())
+			if err == nil {
+				value = v
+				done.Store(true)
+			}
+			return v, err
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package memoize
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnceValueCachesSuccess(t *testing.T) {
+	var calls int32
+	get := OnceValue(func() (int, error) {
+		return int(atomic.AddInt32(&calls, 1)), nil
+	})
+
+	for i := 0; i < 3; i++ {
+		v, err := get()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, v, "Successful value should be cached")
+	}
+	assert.Equal(t, int32(1), calls)
+}
+
+func TestOnceValueRetriesAfterError(t *testing.T) {
+	errUnavailable := errors.New("unavailable")
+	var calls int32
+	get := OnceValue(func() (string, error) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			return "", errUnavailable
+		}
+		return "client", nil
+	})
+
+	_, err := get()
+	assert.ErrorIs(t, err, errUnavailable)
+	_, err = get()
+	assert.ErrorIs(t, err, errUnavailable, "Errors should not be cached")
+
+	v, err := get()
+	assert.NoError(t, err)
+	assert.Equal(t, "client", v)
+	_, _ = get()
+	assert.Equal(t, int32(3), calls, "No calls should happen after a success")
+}
+
+func TestOnceValueDeduplicatesConcurrentCalls(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	get := OnceValue(func() (int, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return 7, nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, _ := get()
+			assert.Equal(t, 7, v)
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls, "Concurrent first calls should share one attempt")
+}
+
+func TestOnceValueContext(t *testing.T) {
+	release := make(chan struct{})
+	get := OnceValueContext(func(context.Context) (int, error) {
+		<-release
+		return 1, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := get(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Caller should stop waiting when its context expires")
+
+	close(release)
+	v, err := get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, v, "Abandoned attempt should still complete and be cached")
+}
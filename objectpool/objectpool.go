@@ -0,0 +1,266 @@
+package objectpool
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+)
+
+var (
+	// ErrPoolClosed is returned by Get after the pool has been closed.
+	ErrPoolClosed = errors.New("objectpool: pool is closed")
+	// ErrUnbalancedPut is reported when Put or Discard is called for an object that is not outstanding.
+	ErrUnbalancedPut = errors.New("objectpool: object returned without a matching Get")
+)
+
+// Options configures an ObjectPool. Zero values disable the corresponding hook or limit.
+type Options[T any] struct {
+	Reset          func(obj T)      // Prepares a returned object for reuse
+	Validate       func(obj T) bool // Reports whether an idle object may still be handed out
+	Destroy        func(obj T)      // Releases an object that leaves the pool
+	MaxIdle        int              // Maximum number of idle objects kept; extra returned objects are destroyed
+	MaxOutstanding int              // Maximum number of objects handed out at once; Get blocks at the limit
+	OnMisuse       func(err error)  // Called on an unbalanced Put or Discard; when nil, such a call panics
+}
+
+// Stats is a snapshot of pool activity.
+type Stats struct {
+	Idle        int    // Objects waiting in the pool
+	Outstanding int    // Objects currently handed out
+	Created     uint64 // Objects built by the factory
+	Destroyed   uint64 // Objects destroyed because they were invalid, discarded or exceeded MaxIdle
+	Hits        uint64 // Gets served from an idle object
+	Misses      uint64 // Gets that had to build a new object
+	Waits       uint64 // Gets that blocked on MaxOutstanding
+}
+
+// ObjectPool is a typed pool of reusable objects. Unlike sync.Pool, idle objects are only dropped
+// by the pool's own limits and hooks, never by the garbage collector.
+type ObjectPool[T any] struct {
+	factory func() (T, error)
+	options Options[T]
+	slots   chan struct{} // One token per outstanding object when MaxOutstanding is set
+	done    chan struct{} // Closed by Close to wake blocked Gets
+
+	mu      sync.Mutex
+	idle    []T              // Idle objects, most recently returned last
+	idleSet map[any]struct{} // The idle objects when they are pointers, so a double Put is found in O(1)
+	stats   Stats
+	closed  bool
+}
+
+// NewObjectPool creates an ObjectPool that builds new objects with factory.
+func NewObjectPool[T any](factory func() (T, error), options Options[T]) *ObjectPool[T] {
+	p := &ObjectPool[T]{
+		factory: factory,
+		options: options,
+		done:    make(chan struct{}),
+	}
+	// Only pointer objects can be told apart, so only they are tracked for double Puts.
+	if kind := reflect.TypeFor[T]().Kind(); kind == reflect.Pointer || kind == reflect.Chan {
+		p.idleSet = make(map[any]struct{})
+	}
+	if options.MaxOutstanding > 0 {
+		p.slots = make(chan struct{}, options.MaxOutstanding)
+	}
+	return p
+}
+
+// Get returns an idle object or builds a new one. It blocks while MaxOutstanding objects are handed out,
+// until one is returned or ctx is done.
+func (p *ObjectPool[T]) Get(ctx context.Context) (T, error) {
+	var zero T
+	if err := p.acquire(ctx); err != nil {
+		return zero, err
+	}
+
+	for {
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			p.release()
+			return zero, ErrPoolClosed
+		}
+		n := len(p.idle)
+		if n == 0 {
+			p.stats.Misses++
+			p.stats.Outstanding++
+			p.mu.Unlock()
+			break
+		}
+		obj := p.idle[n-1]
+		p.idle[n-1] = zero
+		p.idle = p.idle[:n-1]
+		if p.idleSet != nil {
+			delete(p.idleSet, obj)
+		}
+		p.mu.Unlock()
+
+		if p.options.Validate == nil || p.options.Validate(obj) {
+			p.mu.Lock()
+			p.stats.Hits++
+			p.stats.Outstanding++
+			p.mu.Unlock()
+			return obj, nil
+		}
+		p.destroy(obj)
+	}
+
+	obj, err := p.factory()
+	p.mu.Lock()
+	if err != nil {
+		p.stats.Outstanding--
+	} else {
+		p.stats.Created++
+	}
+	p.mu.Unlock()
+	if err != nil {
+		p.release()
+		return zero, err
+	}
+	return obj, nil
+}
+
+// Put returns an object obtained from Get to the pool. The object is reset, and destroyed instead of
+// kept if it is invalid, the idle limit is reached or the pool is closed.
+// Returning an object that is not outstanding, such as one already returned, is reported through
+// Options.OnMisuse and the object is left alone.
+func (p *ObjectPool[T]) Put(obj T) {
+	if !p.returned(obj) {
+		return
+	}
+	if p.options.Reset != nil {
+		p.options.Reset(obj)
+	}
+	valid := p.options.Validate == nil || p.options.Validate(obj)
+
+	p.mu.Lock()
+	pooled := valid && !p.closed && (p.options.MaxIdle <= 0 || len(p.idle) < p.options.MaxIdle)
+	if pooled {
+		p.idle = append(p.idle, obj)
+		if p.idleSet != nil {
+			p.idleSet[obj] = struct{}{}
+		}
+	}
+	p.mu.Unlock()
+
+	if !pooled {
+		p.destroy(obj)
+	}
+	p.release()
+}
+
+// Discard destroys an object obtained from Get instead of returning it, for example after it broke.
+// An unbalanced Discard is reported like an unbalanced Put.
+func (p *ObjectPool[T]) Discard(obj T) {
+	if !p.returned(obj) {
+		return
+	}
+	p.destroy(obj)
+	p.release()
+}
+
+// Stats returns a snapshot of the pool counters.
+func (p *ObjectPool[T]) Stats() Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats := p.stats
+	stats.Idle = len(p.idle)
+	return stats
+}
+
+// Close destroys all idle objects. Blocked and later Gets fail with ErrPoolClosed and objects returned later
+// are destroyed.
+func (p *ObjectPool[T]) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	idle := p.idle
+	p.idle = nil
+	if p.idleSet != nil {
+		clear(p.idleSet)
+	}
+	p.closed = true
+	close(p.done)
+	p.mu.Unlock()
+
+	for _, obj := range idle {
+		p.destroy(obj)
+	}
+}
+
+// acquire takes an outstanding slot when MaxOutstanding is set.
+func (p *ObjectPool[T]) acquire(ctx context.Context) error {
+	if p.slots == nil {
+		return ctx.Err()
+	}
+	select {
+	case p.slots <- struct{}{}:
+		return nil
+	default:
+	}
+
+	p.mu.Lock()
+	p.stats.Waits++
+	p.mu.Unlock()
+	select {
+	case p.slots <- struct{}{}:
+		return nil
+	case <-p.done:
+		return ErrPoolClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees an outstanding slot when MaxOutstanding is set. It never blocks.
+func (p *ObjectPool[T]) release() {
+	if p.slots == nil {
+		return
+	}
+	select {
+	case <-p.slots:
+	default:
+	}
+}
+
+// returned records obj as no longer outstanding. An unbalanced return is reported and false is returned.
+func (p *ObjectPool[T]) returned(obj T) bool {
+	p.mu.Lock()
+	ok := p.stats.Outstanding > 0 && !p.isIdle(obj)
+	if ok {
+		p.stats.Outstanding--
+	}
+	p.mu.Unlock()
+
+	if !ok {
+		if p.options.OnMisuse == nil {
+			panic(ErrUnbalancedPut)
+		}
+		p.options.OnMisuse(ErrUnbalancedPut)
+	}
+	return ok
+}
+
+// isIdle reports whether obj is already among the idle objects. Only pointer objects can be told apart,
+// so for other types it always returns false. The caller must hold p.mu.
+func (p *ObjectPool[T]) isIdle(obj T) bool {
+	if p.idleSet == nil {
+		return false // Looking up an unhashable obj, such as a slice, would panic even in a nil map
+	}
+	_, ok := p.idleSet[obj]
+	return ok
+}
+
+// destroy counts and releases an object leaving the pool.
+func (p *ObjectPool[T]) destroy(obj T) {
+	p.mu.Lock()
+	p.stats.Destroyed++
+	p.mu.Unlock()
+	if p.options.Destroy != nil {
+		p.options.Destroy(obj)
+	}
+}
@@ -0,0 +1,37 @@
+package objectpool
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+)
+
+func BenchmarkObjectPoolGetPut(b *testing.B) {
+	p := NewObjectPool(func() (*bytes.Buffer, error) { return new(bytes.Buffer), nil }, Options[*bytes.Buffer]{
+		Reset:   func(buf *bytes.Buffer) { buf.Reset() },
+		MaxIdle: 64,
+	})
+	ctx := context.Background()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			buf, _ := p.Get(ctx)
+			p.Put(buf)
+		}
+	})
+}
+
+func BenchmarkSyncPoolGetPut(b *testing.B) {
+	p := sync.Pool{New: func() any { return new(bytes.Buffer) }}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			buf := p.Get().(*bytes.Buffer)
+			buf.Reset()
+			p.Put(buf)
+		}
+	})
+}
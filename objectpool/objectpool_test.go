@@ -0,0 +1,260 @@
+package objectpool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// resource is a pooled test object.
+type resource struct {
+	id     int
+	broken bool
+	used   bool
+	closed bool
+}
+
+func newResourcePool(options Options[*resource]) *ObjectPool[*resource] {
+	next := 0
+	return NewObjectPool(func() (*resource, error) {
+		next++
+		return &resource{id: next}, nil
+	}, options)
+}
+
+func TestObjectPoolReuse(t *testing.T) {
+	p := newResourcePool(Options[*resource]{})
+	ctx := context.Background()
+
+	r1, err := p.Get(ctx)
+	assert.NoError(t, err)
+	p.Put(r1)
+
+	r2, err := p.Get(ctx)
+	assert.NoError(t, err)
+	assert.Same(t, r1, r2, "Returned objects should be reused")
+
+	stats := p.Stats()
+	assert.Equal(t, uint64(1), stats.Created)
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+	assert.Equal(t, 1, stats.Outstanding)
+}
+
+func TestObjectPoolResetAndValidate(t *testing.T) {
+	var destroyed []int
+	p := newResourcePool(Options[*resource]{
+		Reset:    func(r *resource) { r.used = false },
+		Validate: func(r *resource) bool { return !r.broken },
+		Destroy:  func(r *resource) { destroyed = append(destroyed, r.id) },
+	})
+	ctx := context.Background()
+
+	r, _ := p.Get(ctx)
+	r.used = true
+	p.Put(r)
+	r, _ = p.Get(ctx)
+	assert.False(t, r.used, "Reset should run before an object is reused")
+
+	r.broken = true
+	p.Put(r)
+	assert.Equal(t, []int{1}, destroyed, "Invalid objects should be destroyed on Put")
+
+	r, _ = p.Get(ctx)
+	assert.Equal(t, 2, r.id, "A new object should be built after the invalid one was dropped")
+	p.Put(r)
+	r.broken = true // Breaks while idle
+	r, _ = p.Get(ctx)
+	assert.Equal(t, 3, r.id, "Idle objects failing validation should not be handed out")
+	assert.Equal(t, []int{1, 2}, destroyed)
+}
+
+func TestObjectPoolMaxIdle(t *testing.T) {
+	p := newResourcePool(Options[*resource]{MaxIdle: 1})
+	ctx := context.Background()
+
+	r1, _ := p.Get(ctx)
+	r2, _ := p.Get(ctx)
+	p.Put(r1)
+	p.Put(r2)
+
+	stats := p.Stats()
+	assert.Equal(t, 1, stats.Idle, "Only MaxIdle objects should be kept")
+	assert.Equal(t, uint64(1), stats.Destroyed, "Objects beyond MaxIdle should be destroyed")
+}
+
+func TestObjectPoolMaxOutstanding(t *testing.T) {
+	p := newResourcePool(Options[*resource]{MaxOutstanding: 1})
+	ctx := context.Background()
+
+	r, _ := p.Get(ctx)
+
+	timeout, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err := p.Get(timeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Get should block at the outstanding limit")
+
+	got := make(chan *resource)
+	go func() {
+		r, _ := p.Get(ctx)
+		got <- r
+	}()
+	time.Sleep(10 * time.Millisecond)
+	p.Put(r)
+
+	select {
+	case r2 := <-got:
+		assert.Same(t, r, r2, "Blocked Get should receive the returned object")
+	case <-time.After(time.Second):
+		t.Fatal("Blocked Get was not woken by Put")
+	}
+	assert.Equal(t, uint64(2), p.Stats().Waits)
+}
+
+func TestObjectPoolDiscard(t *testing.T) {
+	var destroyed int
+	p := newResourcePool(Options[*resource]{MaxOutstanding: 1, Destroy: func(*resource) { destroyed++ }})
+	ctx := context.Background()
+
+	r, _ := p.Get(ctx)
+	p.Discard(r)
+	assert.Equal(t, 1, destroyed, "Discard should destroy the object")
+
+	r, err := p.Get(ctx)
+	assert.NoError(t, err, "Discard should free the outstanding slot")
+	assert.Equal(t, 2, r.id)
+}
+
+func TestObjectPoolFactoryError(t *testing.T) {
+	errFactory := errors.New("dial failed")
+	p := NewObjectPool(func() (int, error) { return 0, errFactory }, Options[int]{MaxOutstanding: 1})
+
+	_, err := p.Get(context.Background())
+	assert.ErrorIs(t, err, errFactory)
+	_, err = p.Get(context.Background())
+	assert.ErrorIs(t, err, errFactory, "A failed build should release its outstanding slot")
+	assert.Equal(t, 0, p.Stats().Outstanding)
+}
+
+func TestObjectPoolClose(t *testing.T) {
+	p := newResourcePool(Options[*resource]{Destroy: func(r *resource) { r.closed = true }})
+	ctx := context.Background()
+
+	idle, _ := p.Get(ctx)
+	held, _ := p.Get(ctx)
+	p.Put(idle)
+	p.Close()
+	assert.True(t, idle.closed, "Close should destroy idle objects")
+
+	_, err := p.Get(ctx)
+	assert.ErrorIs(t, err, ErrPoolClosed)
+
+	p.Put(held)
+	assert.True(t, held.closed, "Objects returned after Close should be destroyed")
+}
+
+func TestObjectPoolUnbalancedPut(t *testing.T) {
+	var misuses []error
+	p := newResourcePool(Options[*resource]{MaxOutstanding: 2, OnMisuse: func(err error) { misuses = append(misuses, err) }})
+	ctx := context.Background()
+
+	r, _ := p.Get(ctx)
+	p.Put(r)
+	done := make(chan struct{})
+	go func() {
+		p.Put(r)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("A second Put of the same object should not block")
+	}
+	assert.Equal(t, []error{ErrUnbalancedPut}, misuses, "A double Put should be reported")
+
+	stats := p.Stats()
+	assert.Equal(t, 0, stats.Outstanding, "A double Put should not change the outstanding count")
+	assert.Equal(t, 1, stats.Idle, "A double Put should not pool the object twice")
+
+	a, _ := p.Get(ctx)
+	b, _ := p.Get(ctx)
+	assert.NotSame(t, a, b, "Two Gets should never receive the same object")
+
+	p.Put(a)
+	p.Put(a)
+	assert.Len(t, misuses, 2, "Returning an idle object while others are outstanding should be reported")
+	p.Discard(b)
+	p.Discard(&resource{})
+	assert.Len(t, misuses, 3, "Discarding an object that is not outstanding should be reported")
+	assert.Equal(t, 0, p.Stats().Outstanding)
+}
+
+func TestObjectPoolUnbalancedPutPanicsByDefault(t *testing.T) {
+	p := newResourcePool(Options[*resource]{})
+	assert.PanicsWithValue(t, ErrUnbalancedPut, func() { p.Put(&resource{}) }, "An unbalanced Put should panic without OnMisuse")
+}
+
+func TestObjectPoolCloseWakesBlockedGet(t *testing.T) {
+	p := newResourcePool(Options[*resource]{MaxOutstanding: 1})
+	ctx := context.Background()
+	_, _ = p.Get(ctx)
+
+	errs := make(chan error)
+	go func() {
+		_, err := p.Get(ctx)
+		errs <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	p.Close()
+
+	select {
+	case err := <-errs:
+		assert.ErrorIs(t, err, ErrPoolClosed, "A blocked Get should fail with ErrPoolClosed")
+	case <-time.After(time.Second):
+		t.Fatal("Close should wake a blocked Get")
+	}
+	p.Close() // Closing twice should be harmless
+}
+
+func TestObjectPoolBuffers(t *testing.T) {
+	p := NewObjectPool(func() (*bytes.Buffer, error) { return new(bytes.Buffer), nil }, Options[*bytes.Buffer]{
+		Reset:   func(b *bytes.Buffer) { b.Reset() },
+		MaxIdle: 4,
+	})
+
+	b, _ := p.Get(context.Background())
+	b.WriteString("hello")
+	p.Put(b)
+
+	b, _ = p.Get(context.Background())
+	assert.Equal(t, 0, b.Len(), "Pooled buffers should come back empty")
+}
+
+func TestObjectPoolSlices(t *testing.T) {
+	p := NewObjectPool(func() ([]byte, error) { return make([]byte, 0, 64), nil }, Options[[]byte]{})
+	ctx := context.Background()
+
+	b, _ := p.Get(ctx)
+	assert.NotPanics(t, func() { p.Put(b) }, "Objects that cannot be map keys should still be pooled")
+	b, _ = p.Get(ctx)
+	p.Discard(b)
+	assert.Equal(t, 0, p.Stats().Outstanding)
+}
+
+func TestObjectPoolIdleSetFollowsIdleObjects(t *testing.T) {
+	p := newResourcePool(Options[*resource]{})
+	ctx := context.Background()
+
+	r, _ := p.Get(ctx)
+	p.Put(r)
+	r, _ = p.Get(ctx)
+	assert.Empty(t, p.idleSet, "An object handed out again should leave the idle set")
+	p.Put(r)
+	assert.Len(t, p.idleSet, 1)
+	p.Close()
+	assert.Empty(t, p.idleSet, "Close should clear the idle set")
+}
@@ -0,0 +1,148 @@
+package priorityqueue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrQueueClosed is returned when putting into a closed queue, or taking from one that is closed and empty.
+	ErrQueueClosed = errors.New("priorityqueue: queue closed")
+	// ErrQueueFull is returned by Offer when a bounded queue has no room.
+	ErrQueueFull = errors.New("priorityqueue: queue full")
+)
+
+// BlockingPriorityQueue is a priority queue that is safe for concurrent use.
+// Take blocks until an item is available and, when a capacity is set, Put blocks until there is room.
+type BlockingPriorityQueue[T any] struct {
+	mu       sync.Mutex
+	pq       *PriorityQueue[T]
+	capacity int           // Maximum number of items, 0 for unbounded
+	notEmpty chan struct{} // Closed when an item is added; nil while nobody waits
+	notFull  chan struct{} // Closed when an item is removed; nil while nobody waits
+	closed   bool
+}
+
+// NewBlockingPriorityQueue initializes a blocking priority queue with a custom comparator.
+// A capacity of zero or less makes the queue unbounded.
+func NewBlockingPriorityQueue[T any](comparator func(a, b T) bool, capacity int) *BlockingPriorityQueue[T] {
+	return &BlockingPriorityQueue[T]{pq: NewPriorityQueue(comparator), capacity: max(capacity, 0)}
+}
+
+// Len returns the number of elements in the queue
+func (q *BlockingPriorityQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.Len()
+}
+
+// Put adds value to the queue, blocking while the queue is full until there is room or ctx is done.
+func (q *BlockingPriorityQueue[T]) Put(ctx context.Context, value T) error {
+	q.mu.Lock()
+	for !q.closed && q.full() {
+		wait := wakeup(&q.notFull)
+		q.mu.Unlock()
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		q.mu.Lock()
+	}
+	defer q.mu.Unlock()
+	return q.put(value)
+}
+
+// Offer adds value to the queue without blocking. It returns ErrQueueFull if the queue has no room.
+func (q *BlockingPriorityQueue[T]) Offer(value T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.closed && q.full() {
+		return ErrQueueFull
+	}
+	return q.put(value)
+}
+
+// Take removes and returns the item with the highest priority, blocking until one is available or ctx is done.
+// Once the queue is closed, Take keeps returning the remaining items and then ErrQueueClosed.
+func (q *BlockingPriorityQueue[T]) Take(ctx context.Context) (T, error) {
+	q.mu.Lock()
+	for !q.closed && q.pq.Len() == 0 {
+		wait := wakeup(&q.notEmpty)
+		q.mu.Unlock()
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+		q.mu.Lock()
+	}
+	defer q.mu.Unlock()
+	value, ok := q.take()
+	if !ok {
+		return value, ErrQueueClosed
+	}
+	return value, nil
+}
+
+// Poll removes and returns the item with the highest priority, waiting up to timeout for one to arrive.
+// It returns false if no item became available in time or the queue is closed and empty.
+func (q *BlockingPriorityQueue[T]) Poll(timeout time.Duration) (T, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	value, err := q.Take(ctx)
+	return value, err == nil
+}
+
+// Close stops the queue from accepting new items and wakes every blocked Put and Take.
+// Items already queued can still be taken.
+func (q *BlockingPriorityQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	broadcast(&q.notEmpty)
+	broadcast(&q.notFull)
+}
+
+// full reports whether a bounded queue has reached its capacity. The caller must hold q.mu.
+func (q *BlockingPriorityQueue[T]) full() bool {
+	return q.capacity > 0 && q.pq.Len() >= q.capacity
+}
+
+// put adds value and wakes waiting takers. The caller must hold q.mu.
+func (q *BlockingPriorityQueue[T]) put(value T) error {
+	if q.closed {
+		return ErrQueueClosed
+	}
+	q.pq.Enqueue(value)
+	broadcast(&q.notEmpty)
+	return nil
+}
+
+// take removes the top item and wakes waiting putters. The caller must hold q.mu.
+func (q *BlockingPriorityQueue[T]) take() (T, bool) {
+	value, ok := q.pq.Dequeue()
+	if ok {
+		broadcast(&q.notFull)
+	}
+	return value, ok
+}
+
+// wakeup returns the channel a waiter blocks on, creating it on first use.
+func wakeup(ch *chan struct{}) chan struct{} {
+	if *ch == nil {
+		*ch = make(chan struct{})
+	}
+	return *ch
+}
+
+// broadcast wakes everyone waiting on ch.
+func broadcast(ch *chan struct{}) {
+	if *ch != nil {
+		close(*ch)
+		*ch = nil
+	}
+}
@@ -0,0 +1,140 @@
+package priorityqueue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newIntBlockingQueue(capacity int) *BlockingPriorityQueue[int] {
+	return NewBlockingPriorityQueue(func(a, b int) bool { return a < b }, capacity)
+}
+
+func TestBlockingPriorityQueueOrder(t *testing.T) {
+	q := newIntBlockingQueue(0)
+	ctx := context.Background()
+	for _, v := range []int{5, 1, 3} {
+		assert.NoError(t, q.Put(ctx, v), "Put should succeed on an unbounded queue")
+	}
+	assert.Equal(t, 3, q.Len(), "Len should report the queued items")
+
+	for _, want := range []int{1, 3, 5} {
+		got, err := q.Take(ctx)
+		assert.NoError(t, err, "Take should succeed while items are queued")
+		assert.Equal(t, want, got, "Take should return items in priority order")
+	}
+}
+
+func TestBlockingPriorityQueueTakeBlocks(t *testing.T) {
+	q := newIntBlockingQueue(0)
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_ = q.Put(context.Background(), 7)
+	}()
+
+	v, err := q.Take(context.Background())
+	assert.NoError(t, err, "Take should return once an item is put")
+	assert.Equal(t, 7, v, "Take should return the item that was put")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = q.Take(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Take should give up when the context expires")
+}
+
+func TestBlockingPriorityQueuePoll(t *testing.T) {
+	q := newIntBlockingQueue(0)
+	_, ok := q.Poll(10 * time.Millisecond)
+	assert.False(t, ok, "Poll should time out on an empty queue")
+
+	_ = q.Offer(2)
+	v, ok := q.Poll(10 * time.Millisecond)
+	assert.True(t, ok, "Poll should return a queued item")
+	assert.Equal(t, 2, v, "Poll should return the top item")
+}
+
+func TestBlockingPriorityQueueCapacity(t *testing.T) {
+	q := newIntBlockingQueue(2)
+	assert.NoError(t, q.Offer(1), "Offer should succeed while there is room")
+	assert.NoError(t, q.Offer(2), "Offer should succeed while there is room")
+	assert.ErrorIs(t, q.Offer(3), ErrQueueFull, "Offer should reject when the queue is full")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, q.Put(ctx, 3), context.DeadlineExceeded, "Put should block until the context expires when full")
+
+	done := make(chan error)
+	go func() { done <- q.Put(context.Background(), 3) }()
+	time.Sleep(10 * time.Millisecond)
+	_, _ = q.Take(context.Background())
+	assert.NoError(t, <-done, "Put should succeed once an item is taken")
+	assert.Equal(t, 2, q.Len(), "The queue should stay within its capacity")
+}
+
+func TestBlockingPriorityQueueCloseWakesWaiters(t *testing.T) {
+	q := newIntBlockingQueue(1)
+	_ = q.Offer(1)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	var putErr error
+	go func() {
+		defer wg.Done()
+		putErr = q.Put(context.Background(), 2)
+	}()
+
+	empty := newIntBlockingQueue(0)
+	var takeErr error
+	go func() {
+		defer wg.Done()
+		_, takeErr = empty.Take(context.Background())
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	q.Close()
+	empty.Close()
+	wg.Wait()
+
+	assert.ErrorIs(t, putErr, ErrQueueClosed, "Close should wake a blocked Put with ErrQueueClosed")
+	assert.ErrorIs(t, takeErr, ErrQueueClosed, "Close should wake a blocked Take with ErrQueueClosed")
+
+	v, err := q.Take(context.Background())
+	assert.NoError(t, err, "Items queued before Close should still be taken")
+	assert.Equal(t, 1, v, "Take should return the remaining item")
+	_, err = q.Take(context.Background())
+	assert.ErrorIs(t, err, ErrQueueClosed, "Take should report ErrQueueClosed once drained")
+}
+
+func TestBlockingPriorityQueueConcurrent(t *testing.T) {
+	const producers, perProducer = 4, 250
+	q := newIntBlockingQueue(16)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				_ = q.Put(ctx, i)
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		q.Close()
+	}()
+
+	count := 0
+	for {
+		if _, err := q.Take(ctx); err != nil {
+			assert.ErrorIs(t, err, ErrQueueClosed, "Take should stop with ErrQueueClosed")
+			break
+		}
+		count++
+	}
+	assert.Equal(t, producers*perProducer, count, "Every item put should be taken exactly once")
+}
@@ -0,0 +1,131 @@
+package priorityqueue
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/vd09/go-generic-utils/clock"
+)
+
+// delayed is an item of a DelayQueue together with the time it becomes available.
+type delayed[T any] struct {
+	value   T
+	readyAt time.Time
+}
+
+// DelayQueue is a concurrency-safe queue whose items only become available once their ready-at time has passed.
+// Items due at the same time are taken in insertion order.
+type DelayQueue[T any] struct {
+	mu      sync.Mutex
+	pq      *PriorityQueue[delayed[T]] // Ordered by ready-at time, earliest first
+	clock   clock.Clock
+	changed chan struct{} // Closed when the earliest item changes or the queue closes; nil while nobody waits
+	closed  bool
+}
+
+// NewDelayQueue creates an empty DelayQueue. A nil clk uses clock.Real.
+func NewDelayQueue[T any](clk clock.Clock) *DelayQueue[T] {
+	if clk == nil {
+		clk = clock.Real
+	}
+	return &DelayQueue[T]{
+		pq: NewStablePriorityQueue(func(a, b delayed[T]) bool {
+			return a.readyAt.Before(b.readyAt)
+		}),
+		clock: clk,
+	}
+}
+
+// Len returns the number of queued items, due or not.
+func (q *DelayQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.pq.Len()
+}
+
+// Put adds value to the queue, to become available at readyAt.
+func (q *DelayQueue[T]) Put(value T, readyAt time.Time) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return ErrQueueClosed
+	}
+	head, ok := q.pq.Peek()
+	q.pq.Enqueue(delayed[T]{value: value, readyAt: readyAt})
+	if !ok || readyAt.Before(head.readyAt) {
+		broadcast(&q.changed) // Waiters re-arm their timers for the new earliest item
+	}
+	return nil
+}
+
+// PutAfter adds value to the queue, to become available once delay has elapsed.
+func (q *DelayQueue[T]) PutAfter(value T, delay time.Duration) error {
+	return q.Put(value, q.clock.Now().Add(delay))
+}
+
+// Poll removes and returns the earliest item if it is due, without blocking.
+func (q *DelayQueue[T]) Poll() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if head, ok := q.pq.Peek(); ok && !head.readyAt.After(q.clock.Now()) {
+		q.pq.Dequeue()
+		return head.value, true
+	}
+	var zero T
+	return zero, false
+}
+
+// Take removes and returns the earliest item, blocking until it is due or ctx is done.
+// Once the queue is closed, Take still returns items that are already due and otherwise ErrQueueClosed.
+func (q *DelayQueue[T]) Take(ctx context.Context) (T, error) {
+	var zero T
+	q.mu.Lock()
+	for {
+		head, ok := q.pq.Peek()
+		var delay time.Duration
+		if ok {
+			delay = head.readyAt.Sub(q.clock.Now())
+			if delay <= 0 {
+				q.pq.Dequeue()
+				q.mu.Unlock()
+				return head.value, nil
+			}
+		}
+		if q.closed {
+			q.mu.Unlock()
+			return zero, ErrQueueClosed
+		}
+
+		changed := wakeup(&q.changed)
+		q.mu.Unlock()
+		var timer clock.Timer
+		var due <-chan time.Time
+		if ok {
+			timer = q.clock.NewTimer(delay)
+			due = timer.C()
+		}
+		var err error
+		select {
+		case <-due:
+		case <-changed:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if err != nil {
+			return zero, err
+		}
+		q.mu.Lock()
+	}
+}
+
+// Close stops the queue from accepting new items and wakes every blocked Take.
+func (q *DelayQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	broadcast(&q.changed)
+}
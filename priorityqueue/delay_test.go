@@ -0,0 +1,113 @@
+package priorityqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vd09/go-generic-utils/clock"
+)
+
+// waitForTimers blocks until clk has n active timers, so the test knows Take is waiting.
+func waitForTimers(t *testing.T, clk *clock.Fake, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for clk.Timers() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d active timers, got %d", n, clk.Timers())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestDelayQueueTakeWaitsUntilDue(t *testing.T) {
+	clk := clock.NewFake(time.Unix(0, 0))
+	q := NewDelayQueue[string](clk)
+	assert.NoError(t, q.PutAfter("later", 10*time.Second), "Put should succeed on an open queue")
+	assert.NoError(t, q.PutAfter("soon", 5*time.Second), "Put should succeed on an open queue")
+
+	_, ok := q.Poll()
+	assert.False(t, ok, "Poll should not return an item before it is due")
+
+	result := make(chan string)
+	go func() {
+		v, _ := q.Take(context.Background())
+		result <- v
+	}()
+
+	waitForTimers(t, clk, 1)
+	clk.Advance(4 * time.Second)
+	select {
+	case <-result:
+		t.Fatal("Take should not return before the earliest item is due")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	clk.Advance(time.Second)
+	assert.Equal(t, "soon", <-result, "Take should return the earliest item once it is due")
+	assert.Equal(t, 1, q.Len(), "The later item should remain queued")
+}
+
+func TestDelayQueueEarlierInsertRearmsTimer(t *testing.T) {
+	clk := clock.NewFake(time.Unix(0, 0))
+	q := NewDelayQueue[string](clk)
+	_ = q.PutAfter("late", time.Hour)
+
+	result := make(chan string)
+	go func() {
+		v, _ := q.Take(context.Background())
+		result <- v
+	}()
+	waitForTimers(t, clk, 1)
+
+	_ = q.PutAfter("early", time.Second)
+	// The waiter stops its hour-long timer and arms one for the new earliest item.
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		clk.Advance(time.Second)
+		select {
+		case v := <-result:
+			assert.Equal(t, "early", v, "Take should return the item inserted ahead of the earlier head")
+			assert.Less(t, clk.Now().Sub(time.Unix(0, 0)), time.Hour, "Take should not wait for the original timer")
+			return
+		case <-time.After(time.Millisecond):
+		}
+	}
+	t.Fatal("Take should return once the earlier item is due")
+}
+
+func TestDelayQueueEqualReadyAtIsFIFO(t *testing.T) {
+	clk := clock.NewFake(time.Unix(0, 0))
+	q := NewDelayQueue[int](clk)
+	at := clk.Now()
+	for i := 0; i < 5; i++ {
+		_ = q.Put(i, at)
+	}
+
+	for i := 0; i < 5; i++ {
+		v, err := q.Take(context.Background())
+		assert.NoError(t, err, "Take should return a due item")
+		assert.Equal(t, i, v, "Items due at the same time should be taken in insertion order")
+	}
+}
+
+func TestDelayQueueContextAndClose(t *testing.T) {
+	q := NewDelayQueue[int](nil)
+	_ = q.PutAfter(1, time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := q.Take(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Take should give up when the context expires")
+
+	done := make(chan error)
+	go func() {
+		_, err := q.Take(context.Background())
+		done <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	q.Close()
+	assert.ErrorIs(t, <-done, ErrQueueClosed, "Close should wake a blocked Take")
+	assert.ErrorIs(t, q.Put(2, time.Now()), ErrQueueClosed, "Put should fail on a closed queue")
+}
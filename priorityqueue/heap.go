@@ -0,0 +1,32 @@
+package priorityqueue
+
+// Heap is the common interface of the priority queues in this package.
+// Every implementation orders elements with a comparator that reports whether a has higher priority than b.
+type Heap[T any] interface {
+	// Len returns the number of elements in the heap
+	Len() int
+	// Peek returns the element with the highest priority without removing it
+	Peek() (T, bool)
+	// Enqueue adds a new element to the heap
+	Enqueue(value T)
+	// Dequeue removes and returns the element with the highest priority
+	Dequeue() (T, bool)
+}
+
+// MeldableHeap is a Heap that can absorb every element of another heap of the same type H,
+// leaving the other heap empty.
+type MeldableHeap[T any, H any] interface {
+	Heap[T]
+	// Meld moves every element of other into the heap
+	Meld(other H)
+}
+
+var (
+	_ Heap[int] = (*PriorityQueue[int])(nil)
+	_ Heap[int] = (*PairingHeap[int])(nil)
+	_ Heap[int] = (*LeftistHeap[int])(nil)
+	_ Heap[int] = (*Queue[int])(nil)
+
+	_ MeldableHeap[int, *PairingHeap[int]] = (*PairingHeap[int])(nil)
+	_ MeldableHeap[int, *LeftistHeap[int]] = (*LeftistHeap[int])(nil)
+)
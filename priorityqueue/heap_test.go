@@ -0,0 +1,150 @@
+package priorityqueue
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// heapConstructors lists every Heap implementation covered by the conformance suite.
+var heapConstructors = map[string]func(comparator func(a, b int) bool) Heap[int]{
+	"PriorityQueue":       func(c func(a, b int) bool) Heap[int] { return NewPriorityQueue(c) },
+	"StablePriorityQueue": func(c func(a, b int) bool) Heap[int] { return NewStablePriorityQueue(c) },
+	"PairingHeap":         func(c func(a, b int) bool) Heap[int] { return NewPairingHeap(c) },
+	"LeftistHeap":         func(c func(a, b int) bool) Heap[int] { return NewLeftistHeap(c) },
+	"Queue":               func(c func(a, b int) bool) Heap[int] { return NewQueue(c) },
+	"StableQueue":         func(c func(a, b int) bool) Heap[int] { return NewStableQueue(c) },
+}
+
+func TestHeapConformance(t *testing.T) {
+	for name, newHeap := range heapConstructors {
+		t.Run(name, func(t *testing.T) {
+			t.Run("Empty", func(t *testing.T) {
+				h := newHeap(func(a, b int) bool { return a < b })
+				assert.Equal(t, 0, h.Len(), "A new heap should be empty")
+				_, ok := h.Peek()
+				assert.False(t, ok, "Peek should return false for an empty heap")
+				_, ok = h.Dequeue()
+				assert.False(t, ok, "Dequeue should return false for an empty heap")
+			})
+
+			t.Run("MinOrder", func(t *testing.T) {
+				testHeapOrder(t, newHeap(func(a, b int) bool { return a < b }), func(a, b int) int { return a - b })
+			})
+
+			t.Run("MaxOrder", func(t *testing.T) {
+				testHeapOrder(t, newHeap(func(a, b int) bool { return a > b }), func(a, b int) int { return b - a })
+			})
+
+			t.Run("Interleaved", func(t *testing.T) {
+				h := newHeap(func(a, b int) bool { return a < b })
+				var model []int
+				r := rand.New(rand.NewSource(2))
+				for i := 0; i < 2000; i++ {
+					if r.Intn(3) > 0 || len(model) == 0 {
+						v := r.Intn(50)
+						h.Enqueue(v)
+						model = append(model, v)
+						continue
+					}
+					want := slices.Min(model)
+					peeked, _ := h.Peek()
+					assert.Equal(t, want, peeked, "Peek should return the highest priority element")
+					got, ok := h.Dequeue()
+					assert.True(t, ok, "Dequeue should return true for a non-empty heap")
+					assert.Equal(t, want, got, "Dequeue should return the highest priority element")
+					idx := slices.Index(model, got)
+					model = slices.Delete(model, idx, idx+1)
+				}
+				assert.Equal(t, len(model), h.Len(), "Len should match the number of queued elements")
+			})
+		})
+	}
+}
+
+// testHeapOrder enqueues random values and checks they are dequeued in the order given by cmp.
+func testHeapOrder(t *testing.T, h Heap[int], cmp func(a, b int) int) {
+	data := rand.New(rand.NewSource(1)).Perm(500)
+	for _, v := range data {
+		h.Enqueue(v)
+	}
+	assert.Equal(t, len(data), h.Len(), "Len should count every enqueued element")
+
+	var got []int
+	for h.Len() > 0 {
+		v, _ := h.Dequeue()
+		got = append(got, v)
+	}
+	slices.SortFunc(data, cmp)
+	assert.Equal(t, data, got, "Elements should be dequeued in priority order")
+}
+
+func TestMeldableHeapConformance(t *testing.T) {
+	t.Run("PairingHeap", func(t *testing.T) {
+		testMeldConformance(t, func(c func(a, b int) bool) *PairingHeap[int] { return NewPairingHeap(c) })
+	})
+	t.Run("LeftistHeap", func(t *testing.T) {
+		testMeldConformance(t, func(c func(a, b int) bool) *LeftistHeap[int] { return NewLeftistHeap(c) })
+	})
+}
+
+// testMeldConformance checks that Meld combines two heaps into one that dequeues every element in order.
+func testMeldConformance[H MeldableHeap[int, H]](t *testing.T, newHeap func(comparator func(a, b int) bool) H) {
+	less := func(a, b int) bool { return a < b }
+	r := rand.New(rand.NewSource(5))
+
+	t.Run("Disjoint", func(t *testing.T) {
+		dst, src := newHeap(less), newHeap(less)
+		var all []int
+		for i := 0; i < 300; i++ {
+			a, b := r.Intn(1000), r.Intn(1000)
+			dst.Enqueue(a)
+			src.Enqueue(b)
+			all = append(all, a, b)
+		}
+
+		dst.Meld(src)
+		assert.Equal(t, len(all), dst.Len(), "Meld should move every element into the receiver")
+		assert.Equal(t, 0, src.Len(), "Meld should leave the other heap empty")
+		_, ok := src.Dequeue()
+		assert.False(t, ok, "The melded-away heap should have nothing to dequeue")
+
+		slices.Sort(all)
+		var got []int
+		for dst.Len() > 0 {
+			v, _ := dst.Dequeue()
+			got = append(got, v)
+		}
+		assert.Equal(t, all, got, "Melded elements should dequeue in priority order")
+	})
+
+	t.Run("EmptySides", func(t *testing.T) {
+		dst, empty := newHeap(less), newHeap(less)
+		dst.Enqueue(2)
+		dst.Enqueue(1)
+		dst.Meld(empty)
+		assert.Equal(t, 2, dst.Len(), "Melding an empty heap should change nothing")
+
+		empty.Meld(dst)
+		assert.Equal(t, 2, empty.Len(), "Melding into an empty heap should take every element")
+		v, _ := empty.Peek()
+		assert.Equal(t, 1, v, "The melded heap should keep its order")
+	})
+
+	t.Run("ReuseAfterMeld", func(t *testing.T) {
+		dst, src := newHeap(less), newHeap(less)
+		src.Enqueue(5)
+		dst.Meld(src)
+		src.Enqueue(3)
+		dst.Enqueue(4)
+		dst.Meld(src)
+		var got []int
+		for dst.Len() > 0 {
+			v, _ := dst.Dequeue()
+			got = append(got, v)
+		}
+		assert.Equal(t, []int{3, 4, 5}, got, "Both heaps should stay usable after Meld")
+	})
+}
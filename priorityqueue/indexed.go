@@ -0,0 +1,126 @@
+package priorityqueue
+
+import (
+	"container/heap"
+)
+
+// Handle refers to an item inside an IndexedPriorityQueue. It stays valid until the item is removed.
+type Handle[T any] struct {
+	value T
+	index int                      // Position in the heap, -1 once the item has left the queue
+	owner *IndexedPriorityQueue[T] // Queue the item was enqueued in
+}
+
+// Value returns the current value of the item.
+func (h *Handle[T]) Value() T {
+	return h.value
+}
+
+// IndexedPriorityQueue is a priority queue whose items can be updated or removed through the handle
+// returned by Enqueue, which makes decrease-key operations O(log n).
+type IndexedPriorityQueue[T any] struct {
+	heap indexedHeap[T]
+}
+
+// indexedHeap implements heap.Interface and keeps every handle's index in sync with its position.
+type indexedHeap[T any] struct {
+	items      []*Handle[T]      // The items in the heap
+	comparator func(a, b T) bool // Custom comparator function
+}
+
+// NewIndexedPriorityQueue initializes a new indexed priority queue with a custom comparator
+func NewIndexedPriorityQueue[T any](comparator func(a, b T) bool) *IndexedPriorityQueue[T] {
+	return &IndexedPriorityQueue[T]{heap: indexedHeap[T]{comparator: comparator}}
+}
+
+// Len returns the number of elements in the queue
+func (q *IndexedPriorityQueue[T]) Len() int {
+	return len(q.heap.items)
+}
+
+// Peek returns the top element of the queue without removing it
+func (q *IndexedPriorityQueue[T]) Peek() (T, bool) {
+	if q.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.heap.items[0].value, true
+}
+
+// Enqueue adds a new item to the queue and returns a handle to it
+func (q *IndexedPriorityQueue[T]) Enqueue(value T) *Handle[T] {
+	h := &Handle[T]{value: value, owner: q}
+	heap.Push(&q.heap, h)
+	return h
+}
+
+// Dequeue removes and returns the item with the highest priority
+func (q *IndexedPriorityQueue[T]) Dequeue() (T, bool) {
+	if q.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return heap.Pop(&q.heap).(*Handle[T]).value, true
+}
+
+// Contains reports whether the item referred to by h is still in the queue
+func (q *IndexedPriorityQueue[T]) Contains(h *Handle[T]) bool {
+	return h != nil && h.owner == q && h.index >= 0
+}
+
+// Update replaces the value of the item referred to by h and restores the heap order.
+// It returns false if the item is no longer in the queue.
+func (q *IndexedPriorityQueue[T]) Update(h *Handle[T], value T) bool {
+	if !q.Contains(h) {
+		return false
+	}
+	h.value = value
+	heap.Fix(&q.heap, h.index)
+	return true
+}
+
+// Remove removes the item referred to by h and returns its value.
+// It returns false if the item is no longer in the queue.
+func (q *IndexedPriorityQueue[T]) Remove(h *Handle[T]) (T, bool) {
+	if !q.Contains(h) {
+		var zero T
+		return zero, false
+	}
+	heap.Remove(&q.heap, h.index)
+	return h.value, true
+}
+
+// Len returns the number of elements in the heap
+func (h *indexedHeap[T]) Len() int {
+	return len(h.items)
+}
+
+// Less uses the custom comparator function to determine the order
+func (h *indexedHeap[T]) Less(i, j int) bool {
+	return h.comparator(h.items[i].value, h.items[j].value)
+}
+
+// Swap swaps the elements at indices i and j and updates their indexes
+func (h *indexedHeap[T]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
+
+// Push adds an item to the heap
+func (h *indexedHeap[T]) Push(x any) {
+	item := x.(*Handle[T])
+	item.index = len(h.items)
+	h.items = append(h.items, item)
+}
+
+// Pop removes and returns the last item and marks it as no longer queued
+func (h *indexedHeap[T]) Pop() any {
+	old := h.items
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil // Avoid retaining the handle
+	item.index = -1
+	h.items = old[:n-1]
+	return item
+}
@@ -0,0 +1,89 @@
+package priorityqueue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexedPriorityQueueBasicOperations(t *testing.T) {
+	q := NewIndexedPriorityQueue(func(a, b int) bool { return a < b })
+
+	h3 := q.Enqueue(3)
+	q.Enqueue(1)
+	q.Enqueue(2)
+	assert.Equal(t, 3, q.Len(), "Queue length should be 3")
+	assert.Equal(t, 3, h3.Value(), "Handle should expose its value")
+
+	peekValue, ok := q.Peek()
+	assert.True(t, ok, "Peek should return true for a non-empty queue")
+	assert.Equal(t, 1, peekValue, "Peek value should be the smallest element")
+
+	for _, want := range []int{1, 2, 3} {
+		got, ok := q.Dequeue()
+		assert.True(t, ok, "Dequeue should return true for a non-empty queue")
+		assert.Equal(t, want, got, "Dequeue should return elements in priority order")
+	}
+	assert.False(t, q.Contains(h3), "A dequeued item should no longer be contained")
+
+	_, ok = q.Dequeue()
+	assert.False(t, ok, "Dequeue should return false for an empty queue")
+}
+
+func TestIndexedPriorityQueueUpdate(t *testing.T) {
+	q := NewIndexedPriorityQueue(func(a, b int) bool { return a < b })
+	q.Enqueue(5)
+	h := q.Enqueue(10)
+	q.Enqueue(7)
+
+	assert.True(t, q.Update(h, 1), "Update should succeed for a queued item")
+	top, _ := q.Peek()
+	assert.Equal(t, 1, top, "Decreasing a key should move the item to the top")
+
+	assert.True(t, q.Update(h, 20), "Update should succeed for a queued item")
+	top, _ = q.Peek()
+	assert.Equal(t, 5, top, "Increasing a key should move the item down")
+
+	var order []int
+	for q.Len() > 0 {
+		v, _ := q.Dequeue()
+		order = append(order, v)
+	}
+	assert.Equal(t, []int{5, 7, 20}, order, "Updated items should dequeue in their new order")
+	assert.False(t, q.Update(h, 0), "Update should fail once the item has left the queue")
+}
+
+func TestIndexedPriorityQueueRemove(t *testing.T) {
+	q := NewIndexedPriorityQueue(func(a, b int) bool { return a < b })
+	handles := make([]*Handle[int], 0, 10)
+	for i := 0; i < 10; i++ {
+		handles = append(handles, q.Enqueue(i))
+	}
+
+	for i := 0; i < 10; i += 2 {
+		v, ok := q.Remove(handles[i])
+		assert.True(t, ok, "Remove should succeed for a queued item")
+		assert.Equal(t, i, v, "Remove should return the removed value")
+	}
+	_, ok := q.Remove(handles[0])
+	assert.False(t, ok, "Removing an item twice should fail")
+	assert.True(t, q.Contains(handles[1]), "Items that were not removed should still be contained")
+
+	var order []int
+	for q.Len() > 0 {
+		v, _ := q.Dequeue()
+		order = append(order, v)
+	}
+	assert.Equal(t, []int{1, 3, 5, 7, 9}, order, "Remaining items should dequeue in order")
+}
+
+func TestIndexedPriorityQueueForeignHandle(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	q1 := NewIndexedPriorityQueue(less)
+	q2 := NewIndexedPriorityQueue(less)
+	h := q1.Enqueue(1)
+
+	assert.False(t, q2.Contains(h), "A handle should only belong to its own queue")
+	assert.False(t, q2.Update(h, 2), "Update should reject a handle from another queue")
+	assert.False(t, q2.Contains(nil), "A nil handle should not be contained")
+}
@@ -0,0 +1,90 @@
+package priorityqueue
+
+// leftistNode is a node of a LeftistHeap. rank is the length of its right spine.
+type leftistNode[T any] struct {
+	value       T
+	rank        int
+	left, right *leftistNode[T]
+}
+
+// LeftistHeap is a binary tree heap whose right spine is kept short, so Enqueue, Dequeue
+// and Meld all run in O(log n) worst case.
+type LeftistHeap[T any] struct {
+	root       *leftistNode[T]
+	size       int
+	comparator func(a, b T) bool // Custom comparator function
+}
+
+// NewLeftistHeap initializes a new leftist heap with a custom comparator
+func NewLeftistHeap[T any](comparator func(a, b T) bool) *LeftistHeap[T] {
+	return &LeftistHeap[T]{comparator: comparator}
+}
+
+// Len returns the number of elements in the heap
+func (h *LeftistHeap[T]) Len() int {
+	return h.size
+}
+
+// Peek returns the element with the highest priority without removing it
+func (h *LeftistHeap[T]) Peek() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	return h.root.value, true
+}
+
+// Enqueue adds a new element to the heap
+func (h *LeftistHeap[T]) Enqueue(value T) {
+	h.root = h.merge(h.root, &leftistNode[T]{value: value, rank: 1})
+	h.size++
+}
+
+// Dequeue removes and returns the element with the highest priority
+func (h *LeftistHeap[T]) Dequeue() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	value := h.root.value
+	h.root = h.merge(h.root.left, h.root.right)
+	h.size--
+	return value, true
+}
+
+// Meld moves every element of other into h in O(log n), leaving other empty. Elements are ordered by h's comparator.
+func (h *LeftistHeap[T]) Meld(other *LeftistHeap[T]) {
+	if other == nil || other == h {
+		return
+	}
+	h.root = h.merge(h.root, other.root)
+	h.size += other.size
+	other.root, other.size = nil, 0
+}
+
+// merge combines two trees along their right spines, whose lengths are logarithmic in the tree sizes.
+func (h *LeftistHeap[T]) merge(a, b *leftistNode[T]) *leftistNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.comparator(b.value, a.value) {
+		a, b = b, a
+	}
+	a.right = h.merge(a.right, b)
+	if rank(a.left) < rank(a.right) {
+		a.left, a.right = a.right, a.left
+	}
+	a.rank = rank(a.right) + 1
+	return a
+}
+
+// rank returns the rank of n, treating nil as zero.
+func rank[T any](n *leftistNode[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.rank
+}
@@ -0,0 +1,41 @@
+package priorityqueue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeftistHeapMeld(t *testing.T) {
+	greater := func(a, b int) bool { return a > b }
+	h1 := NewLeftistHeap(greater)
+	h2 := NewLeftistHeap(greater)
+	for _, v := range []int{5, 1, 9} {
+		h1.Enqueue(v)
+	}
+	for _, v := range []int{4, 0, 7} {
+		h2.Enqueue(v)
+	}
+
+	h1.Meld(h2)
+	assert.Equal(t, 6, h1.Len(), "Meld should move every element into the receiver")
+	assert.Equal(t, 0, h2.Len(), "Meld should leave the other heap empty")
+
+	var got []int
+	for h1.Len() > 0 {
+		v, _ := h1.Dequeue()
+		got = append(got, v)
+	}
+	assert.Equal(t, []int{9, 7, 5, 4, 1, 0}, got, "Melded elements should dequeue in priority order")
+}
+
+func TestLeftistHeapSortedInserts(t *testing.T) {
+	h := NewLeftistHeap(func(a, b int) bool { return a < b })
+	for i := 100000; i > 0; i-- {
+		h.Enqueue(i)
+	}
+	for want := 1; want <= 10; want++ {
+		v, _ := h.Dequeue()
+		assert.Equal(t, want, v, "Dequeue should keep the right spine short for sorted inserts")
+	}
+}
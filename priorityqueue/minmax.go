@@ -0,0 +1,179 @@
+package priorityqueue
+
+import "math/bits"
+
+// MinMaxHeap is a double-ended priority queue. Using the same comparator convention as NewPriorityQueue,
+// the "min" end is the element the comparator ranks first and the "max" end is the one it ranks last.
+// Both ends can be inspected in O(1) and removed in O(log n).
+type MinMaxHeap[T any] struct {
+	items      []T               // Even levels are ordered towards min, odd levels towards max
+	comparator func(a, b T) bool // Custom comparator function
+}
+
+// NewMinMaxHeap initializes a new min-max heap with a custom comparator
+func NewMinMaxHeap[T any](comparator func(a, b T) bool) *MinMaxHeap[T] {
+	return &MinMaxHeap[T]{comparator: comparator}
+}
+
+// Len returns the number of elements in the heap
+func (h *MinMaxHeap[T]) Len() int {
+	return len(h.items)
+}
+
+// Enqueue adds a new item to the heap
+func (h *MinMaxHeap[T]) Enqueue(value T) {
+	h.items = append(h.items, value)
+	h.bubbleUp(len(h.items) - 1)
+}
+
+// PeekMin returns the element ranked first by the comparator without removing it
+func (h *MinMaxHeap[T]) PeekMin() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.items[0], true
+}
+
+// PeekMax returns the element ranked last by the comparator without removing it
+func (h *MinMaxHeap[T]) PeekMax() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.items[h.maxIndex()], true
+}
+
+// PopMin removes and returns the element ranked first by the comparator
+func (h *MinMaxHeap[T]) PopMin() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.removeAt(0), true
+}
+
+// PopMax removes and returns the element ranked last by the comparator
+func (h *MinMaxHeap[T]) PopMax() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.removeAt(h.maxIndex()), true
+}
+
+// maxIndex returns the index of the max element, which is the root or one of its children.
+func (h *MinMaxHeap[T]) maxIndex() int {
+	switch {
+	case len(h.items) == 1:
+		return 0
+	case len(h.items) == 2 || h.less(2, 1):
+		return 1
+	default:
+		return 2
+	}
+}
+
+// removeAt removes the element at index i, filling the gap with the last element.
+func (h *MinMaxHeap[T]) removeAt(i int) T {
+	last := len(h.items) - 1
+	value := h.items[i]
+	h.items[i] = h.items[last]
+	var zero T
+	h.items[last] = zero // Avoid retaining the removed element
+	h.items = h.items[:last]
+	if i < last {
+		h.trickleDown(i)
+	}
+	return value
+}
+
+// less reports whether the element at i ranks before the element at j.
+func (h *MinMaxHeap[T]) less(i, j int) bool {
+	return h.comparator(h.items[i], h.items[j])
+}
+
+// greater reports whether the element at i ranks after the element at j.
+func (h *MinMaxHeap[T]) greater(i, j int) bool {
+	return h.comparator(h.items[j], h.items[i])
+}
+
+// isMinLevel reports whether index i sits on an even (min) level.
+func isMinLevel(i int) bool {
+	return (bits.Len(uint(i+1))-1)%2 == 0
+}
+
+// bubbleUp restores the heap order after an element is appended at index i.
+func (h *MinMaxHeap[T]) bubbleUp(i int) {
+	if i == 0 {
+		return
+	}
+	parent := (i - 1) / 2
+	if isMinLevel(i) {
+		if h.greater(i, parent) {
+			h.swap(i, parent)
+			h.bubbleUpGrandparents(parent, h.greater)
+		} else {
+			h.bubbleUpGrandparents(i, h.less)
+		}
+	} else {
+		if h.less(i, parent) {
+			h.swap(i, parent)
+			h.bubbleUpGrandparents(parent, h.less)
+		} else {
+			h.bubbleUpGrandparents(i, h.greater)
+		}
+	}
+}
+
+// bubbleUpGrandparents moves the element at i up through levels of its own kind while before(i, grandparent).
+func (h *MinMaxHeap[T]) bubbleUpGrandparents(i int, before func(i, j int) bool) {
+	for i >= 3 {
+		grandparent := ((i-1)/2 - 1) / 2
+		if !before(i, grandparent) {
+			return
+		}
+		h.swap(i, grandparent)
+		i = grandparent
+	}
+}
+
+// trickleDown restores the heap order after the element at index i is replaced.
+func (h *MinMaxHeap[T]) trickleDown(i int) {
+	if isMinLevel(i) {
+		h.trickleDownWith(i, h.less)
+	} else {
+		h.trickleDownWith(i, h.greater)
+	}
+}
+
+// trickleDownWith moves the element at i down, comparing it with its children and grandchildren using before.
+func (h *MinMaxHeap[T]) trickleDownWith(i int, before func(i, j int) bool) {
+	n := len(h.items)
+	for {
+		// Find the best of the children and grandchildren of i.
+		best := -1
+		firstChild := 2*i + 1
+		for _, c := range [...]int{firstChild, firstChild + 1, 2*firstChild + 1, 2*firstChild + 2, 2*firstChild + 3, 2*firstChild + 4} {
+			if c < n && (best < 0 || before(c, best)) {
+				best = c
+			}
+		}
+		if best < 0 || !before(best, i) {
+			return
+		}
+		h.swap(i, best)
+		if best <= firstChild+1 {
+			return // A child has no descendants of the same kind below i
+		}
+		if parent := (best - 1) / 2; before(parent, best) {
+			h.swap(best, parent)
+		}
+		i = best
+	}
+}
+
+// swap swaps the elements at indices i and j
+func (h *MinMaxHeap[T]) swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
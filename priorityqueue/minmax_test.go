@@ -0,0 +1,77 @@
+package priorityqueue
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinMaxHeapBasicOperations(t *testing.T) {
+	h := NewMinMaxHeap(func(a, b int) bool { return a < b })
+
+	_, ok := h.PeekMin()
+	assert.False(t, ok, "PeekMin should return false for an empty heap")
+	_, ok = h.PopMax()
+	assert.False(t, ok, "PopMax should return false for an empty heap")
+
+	for _, v := range []int{5, 1, 9, 3, 7} {
+		h.Enqueue(v)
+	}
+	assert.Equal(t, 5, h.Len(), "Heap length should be 5")
+
+	minValue, _ := h.PeekMin()
+	maxValue, _ := h.PeekMax()
+	assert.Equal(t, 1, minValue, "PeekMin should return the smallest element")
+	assert.Equal(t, 9, maxValue, "PeekMax should return the largest element")
+
+	v, _ := h.PopMax()
+	assert.Equal(t, 9, v, "PopMax should return the largest element")
+	v, _ = h.PopMin()
+	assert.Equal(t, 1, v, "PopMin should return the smallest element")
+	v, _ = h.PopMax()
+	assert.Equal(t, 7, v, "PopMax should return the next largest element")
+	assert.Equal(t, 2, h.Len(), "Heap length should be 2 after popping three elements")
+}
+
+func TestMinMaxHeapSingleElement(t *testing.T) {
+	h := NewMinMaxHeap(func(a, b string) bool { return a < b })
+	h.Enqueue("only")
+
+	minValue, _ := h.PeekMin()
+	maxValue, _ := h.PeekMax()
+	assert.Equal(t, "only", minValue, "A single element should be the min")
+	assert.Equal(t, "only", maxValue, "A single element should also be the max")
+
+	v, ok := h.PopMax()
+	assert.True(t, ok, "PopMax should return true for a single element")
+	assert.Equal(t, "only", v, "PopMax should return the single element")
+	assert.Equal(t, 0, h.Len(), "Heap should be empty afterwards")
+}
+
+func TestMinMaxHeapRandomized(t *testing.T) {
+	h := NewMinMaxHeap(func(a, b int) bool { return a < b })
+	var model []int
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 5000; i++ {
+		switch op := r.Intn(4); {
+		case op < 2 || len(model) == 0:
+			v := r.Intn(100)
+			h.Enqueue(v)
+			model = append(model, v)
+		case op == 2:
+			v, ok := h.PopMin()
+			assert.True(t, ok, "PopMin should succeed on a non-empty heap")
+			assert.Equal(t, slices.Min(model), v, "PopMin should return the smallest element")
+			model = slices.Delete(model, slices.Index(model, v), slices.Index(model, v)+1)
+		default:
+			v, ok := h.PopMax()
+			assert.True(t, ok, "PopMax should succeed on a non-empty heap")
+			assert.Equal(t, slices.Max(model), v, "PopMax should return the largest element")
+			model = slices.Delete(model, slices.Index(model, v), slices.Index(model, v)+1)
+		}
+		assert.Equal(t, len(model), h.Len(), "Heap length should match the model")
+	}
+}
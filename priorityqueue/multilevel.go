@@ -0,0 +1,178 @@
+package priorityqueue
+
+import (
+	"errors"
+	"time"
+
+	"github.com/vd09/go-generic-utils/clock"
+	"github.com/vd09/go-generic-utils/queue"
+)
+
+// ErrInvalidBand is returned when enqueuing into a band that does not exist.
+var ErrInvalidBand = errors.New("priorityqueue: invalid band")
+
+// BandPolicy selects how a MultiLevelQueue chooses the band to dequeue from.
+type BandPolicy int
+
+const (
+	StrictPriority     BandPolicy = iota // Always dequeue from the highest priority non-empty band
+	WeightedRoundRobin                   // Visit bands in turn, dequeuing up to each band's weight per visit
+)
+
+// MultiLevelOptions configures a MultiLevelQueue.
+type MultiLevelOptions struct {
+	Bands    int           // Number of bands, band 0 having the highest priority (default 1)
+	Policy   BandPolicy    // How the next band is chosen (default StrictPriority)
+	Weights  []int         // Items dequeued per visit under WeightedRoundRobin (default Bands-i for band i)
+	AgeAfter time.Duration // Wait after which an item is promoted one band up; 0 disables aging
+	Clock    clock.Clock   // Time source for aging (default clock.Real)
+}
+
+// BandStats describes the state of one band of a MultiLevelQueue.
+type BandStats struct {
+	Len        int           // Items currently queued in the band
+	OldestWait time.Duration // How long the front item has waited in the band
+	Enqueued   uint64        // Items enqueued directly into the band
+	Dequeued   uint64        // Items dequeued from the band
+	Promoted   uint64        // Items promoted out of the band by aging
+}
+
+// multiLevelItem is an item of a MultiLevelQueue with the time it entered its current band.
+type multiLevelItem[T any] struct {
+	value   T
+	entered time.Time
+}
+
+// multiLevelBand is a FIFO band with its counters.
+type multiLevelBand[T any] struct {
+	items    *queue.Queue[multiLevelItem[T]]
+	enqueued uint64
+	dequeued uint64
+	promoted uint64
+}
+
+// MultiLevelQueue is a multi-level feedback queue: a fixed number of FIFO bands served by strict priority
+// or weighted round-robin, with optional aging that promotes items which have waited too long so that
+// low bands cannot starve. It is not safe for concurrent use.
+type MultiLevelQueue[T any] struct {
+	bands   []multiLevelBand[T]
+	options MultiLevelOptions
+	size    int
+	current int // Band being visited under WeightedRoundRobin
+	credit  int // Items the current band may still dequeue during this visit
+}
+
+// NewMultiLevelQueue creates an empty MultiLevelQueue configured by options
+func NewMultiLevelQueue[T any](options MultiLevelOptions) *MultiLevelQueue[T] {
+	options.Bands = max(options.Bands, 1)
+	weights := make([]int, options.Bands)
+	for i := range weights {
+		weights[i] = options.Bands - i
+		if i < len(options.Weights) && options.Weights[i] > 0 {
+			weights[i] = options.Weights[i]
+		}
+	}
+	options.Weights = weights
+	if options.Clock == nil {
+		options.Clock = clock.Real
+	}
+
+	q := &MultiLevelQueue[T]{bands: make([]multiLevelBand[T], options.Bands), options: options, credit: weights[0]}
+	for i := range q.bands {
+		q.bands[i].items = queue.NewQueue[multiLevelItem[T]]()
+	}
+	return q
+}
+
+// Len returns the number of items across all bands
+func (q *MultiLevelQueue[T]) Len() int {
+	return q.size
+}
+
+// Bands returns the number of bands
+func (q *MultiLevelQueue[T]) Bands() int {
+	return len(q.bands)
+}
+
+// Enqueue adds value to the back of band. It returns ErrInvalidBand if band is out of range.
+func (q *MultiLevelQueue[T]) Enqueue(value T, band int) error {
+	if band < 0 || band >= len(q.bands) {
+		return ErrInvalidBand
+	}
+	q.bands[band].items.Enqueue(multiLevelItem[T]{value: value, entered: q.options.Clock.Now()})
+	q.bands[band].enqueued++
+	q.size++
+	return nil
+}
+
+// Dequeue promotes aged items, then removes and returns the next item according to the band policy
+// together with the band it was taken from.
+func (q *MultiLevelQueue[T]) Dequeue() (T, int, bool) {
+	if q.size == 0 {
+		var zero T
+		return zero, 0, false
+	}
+	q.age()
+
+	band := q.nextBand()
+	item, _ := q.bands[band].items.Dequeue()
+	q.bands[band].dequeued++
+	q.size--
+	return item.value, band, true
+}
+
+// Stats returns the state of every band, highest priority first
+func (q *MultiLevelQueue[T]) Stats() []BandStats {
+	now := q.options.Clock.Now()
+	stats := make([]BandStats, len(q.bands))
+	for i, band := range q.bands {
+		stats[i] = BandStats{
+			Len:      band.items.Size(),
+			Enqueued: band.enqueued,
+			Dequeued: band.dequeued,
+			Promoted: band.promoted,
+		}
+		if front, ok := band.items.Peek(); ok {
+			stats[i].OldestWait = now.Sub(front.entered)
+		}
+	}
+	return stats
+}
+
+// nextBand returns the band to dequeue from. At least one band must be non-empty.
+func (q *MultiLevelQueue[T]) nextBand() int {
+	if q.options.Policy != WeightedRoundRobin {
+		for i := range q.bands {
+			if !q.bands[i].items.IsEmpty() {
+				return i
+			}
+		}
+	}
+	for q.credit == 0 || q.bands[q.current].items.IsEmpty() {
+		q.current = (q.current + 1) % len(q.bands)
+		q.credit = q.options.Weights[q.current]
+	}
+	q.credit--
+	return q.current
+}
+
+// age moves every item that has waited at least AgeAfter in its band one band up.
+// Bands are visited from the top so a promoted item restarts its wait and moves at most one band per call.
+func (q *MultiLevelQueue[T]) age() {
+	if q.options.AgeAfter <= 0 {
+		return
+	}
+	now := q.options.Clock.Now()
+	for i := 1; i < len(q.bands); i++ {
+		band := &q.bands[i]
+		for {
+			front, ok := band.items.Peek()
+			if !ok || now.Sub(front.entered) < q.options.AgeAfter {
+				break
+			}
+			band.items.Dequeue()
+			band.promoted++
+			q.bands[i-1].items.Enqueue(multiLevelItem[T]{value: front.value, entered: now})
+		}
+	}
+}
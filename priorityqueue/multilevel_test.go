@@ -0,0 +1,100 @@
+package priorityqueue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vd09/go-generic-utils/clock"
+)
+
+// dequeueAll drains q and returns the values in dequeue order.
+func dequeueAll[T any](q *MultiLevelQueue[T]) []T {
+	var values []T
+	for {
+		v, _, ok := q.Dequeue()
+		if !ok {
+			return values
+		}
+		values = append(values, v)
+	}
+}
+
+func TestMultiLevelQueueStrictPriority(t *testing.T) {
+	q := NewMultiLevelQueue[string](MultiLevelOptions{Bands: 3})
+	assert.NoError(t, q.Enqueue("low-1", 2), "Enqueue should accept a valid band")
+	assert.NoError(t, q.Enqueue("high-1", 0), "Enqueue should accept a valid band")
+	assert.NoError(t, q.Enqueue("mid-1", 1), "Enqueue should accept a valid band")
+	assert.NoError(t, q.Enqueue("high-2", 0), "Enqueue should accept a valid band")
+	assert.ErrorIs(t, q.Enqueue("nope", 3), ErrInvalidBand, "Enqueue should reject an out of range band")
+	assert.ErrorIs(t, q.Enqueue("nope", -1), ErrInvalidBand, "Enqueue should reject a negative band")
+
+	assert.Equal(t, 4, q.Len(), "Len should count items across bands")
+	v, band, ok := q.Dequeue()
+	assert.True(t, ok, "Dequeue should succeed on a non-empty queue")
+	assert.Equal(t, "high-1", v, "Dequeue should take from the highest band first")
+	assert.Equal(t, 0, band, "Dequeue should report the band it took from")
+
+	assert.Equal(t, []string{"high-2", "mid-1", "low-1"}, dequeueAll(q), "Bands should be served strictly in order, FIFO within a band")
+	_, _, ok = q.Dequeue()
+	assert.False(t, ok, "Dequeue should return false on an empty queue")
+}
+
+func TestMultiLevelQueueWeightedRoundRobin(t *testing.T) {
+	q := NewMultiLevelQueue[int](MultiLevelOptions{Bands: 2, Policy: WeightedRoundRobin, Weights: []int{3, 1}})
+	for i := 0; i < 6; i++ {
+		_ = q.Enqueue(i, 0)
+		_ = q.Enqueue(100+i, 1)
+	}
+
+	assert.Equal(t, []int{0, 1, 2, 100, 3, 4, 5, 101, 102, 103, 104, 105}, dequeueAll(q),
+		"Bands should be visited in turn, each taking up to its weight")
+}
+
+func TestMultiLevelQueueWeightedRoundRobinSkipsEmptyBands(t *testing.T) {
+	q := NewMultiLevelQueue[int](MultiLevelOptions{Bands: 3, Policy: WeightedRoundRobin})
+	_ = q.Enqueue(1, 2)
+	_ = q.Enqueue(2, 2)
+
+	assert.Equal(t, []int{1, 2}, dequeueAll(q), "Empty bands should be skipped")
+}
+
+func TestMultiLevelQueueAging(t *testing.T) {
+	clk := clock.NewFake(time.Unix(0, 0))
+	q := NewMultiLevelQueue[string](MultiLevelOptions{Bands: 3, AgeAfter: time.Minute, Clock: clk})
+	_ = q.Enqueue("starving", 2)
+	clk.Advance(30 * time.Second)
+	_ = q.Enqueue("busy-1", 0)
+	_ = q.Enqueue("busy-2", 0)
+
+	stats := q.Stats()
+	assert.Equal(t, 30*time.Second, stats[2].OldestWait, "Stats should report how long the front item has waited")
+
+	clk.Advance(30 * time.Second)
+	v, _, _ := q.Dequeue()
+	assert.Equal(t, "busy-1", v, "The top band should still be served first")
+	stats = q.Stats()
+	assert.Equal(t, 1, stats[1].Len, "An item waiting past AgeAfter should be promoted one band")
+	assert.Equal(t, uint64(1), stats[2].Promoted, "Stats should count promotions")
+
+	clk.Advance(time.Minute)
+	_ = q.Enqueue("busy-3", 0)
+	assert.Equal(t, []string{"busy-2", "busy-3", "starving"}, dequeueAll(q), "A repeatedly aged item should join the back of the top band")
+}
+
+func TestMultiLevelQueueStats(t *testing.T) {
+	q := NewMultiLevelQueue[int](MultiLevelOptions{Bands: 2})
+	assert.Equal(t, 2, q.Bands(), "Bands should report the configured number of bands")
+
+	_ = q.Enqueue(1, 0)
+	_ = q.Enqueue(2, 1)
+	_ = q.Enqueue(3, 1)
+	q.Dequeue()
+	q.Dequeue()
+
+	stats := q.Stats()
+	assert.Equal(t, BandStats{Len: 0, Enqueued: 1, Dequeued: 1}, stats[0], "Band 0 should have been drained")
+	assert.Equal(t, 1, stats[1].Len, "Band 1 should still hold an item")
+	assert.Equal(t, uint64(2), stats[1].Enqueued, "Stats should count enqueues per band")
+	assert.Equal(t, uint64(1), stats[1].Dequeued, "Stats should count dequeues per band")
+}
@@ -0,0 +1,109 @@
+package priorityqueue
+
+// pairingNode is a node of a PairingHeap. Children form a singly linked list through sibling.
+type pairingNode[T any] struct {
+	value   T
+	child   *pairingNode[T]
+	sibling *pairingNode[T]
+}
+
+// PairingHeap is a heap-ordered multiway tree. Enqueue, Peek and Meld run in O(1);
+// Dequeue runs in O(log n) amortized.
+type PairingHeap[T any] struct {
+	root       *pairingNode[T]
+	size       int
+	comparator func(a, b T) bool // Custom comparator function
+}
+
+// NewPairingHeap initializes a new pairing heap with a custom comparator
+func NewPairingHeap[T any](comparator func(a, b T) bool) *PairingHeap[T] {
+	return &PairingHeap[T]{comparator: comparator}
+}
+
+// Len returns the number of elements in the heap
+func (h *PairingHeap[T]) Len() int {
+	return h.size
+}
+
+// Peek returns the element with the highest priority without removing it
+func (h *PairingHeap[T]) Peek() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	return h.root.value, true
+}
+
+// Enqueue adds a new element to the heap
+func (h *PairingHeap[T]) Enqueue(value T) {
+	h.root = h.link(h.root, &pairingNode[T]{value: value})
+	h.size++
+}
+
+// Dequeue removes and returns the element with the highest priority
+func (h *PairingHeap[T]) Dequeue() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	value := h.root.value
+	h.root = h.mergePairs(h.root.child)
+	h.size--
+	return value, true
+}
+
+// Meld moves every element of other into h in O(1), leaving other empty. Elements are ordered by h's comparator.
+func (h *PairingHeap[T]) Meld(other *PairingHeap[T]) {
+	if other == nil || other == h {
+		return
+	}
+	h.root = h.link(h.root, other.root)
+	h.size += other.size
+	other.root, other.size = nil, 0
+}
+
+// link makes the lower priority root a child of the other and returns the new root.
+func (h *PairingHeap[T]) link(a, b *pairingNode[T]) *pairingNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.comparator(b.value, a.value) {
+		a, b = b, a
+	}
+	b.sibling = a.child
+	a.child = b
+	return a
+}
+
+// mergePairs combines a list of siblings into one tree with the standard two-pass scheme,
+// iteratively so that long child lists cannot overflow the stack.
+func (h *PairingHeap[T]) mergePairs(first *pairingNode[T]) *pairingNode[T] {
+	// First pass: link siblings in pairs from left to right, collecting the results in reverse order.
+	var pairs *pairingNode[T]
+	for first != nil {
+		a, b := first, first.sibling
+		if b == nil {
+			a.sibling = pairs
+			pairs = a
+			break
+		}
+		first = b.sibling
+		a.sibling, b.sibling = nil, nil
+		linked := h.link(a, b)
+		linked.sibling = pairs
+		pairs = linked
+	}
+
+	// Second pass: link the pairs from right to left into a single tree.
+	var root *pairingNode[T]
+	for pairs != nil {
+		next := pairs.sibling
+		pairs.sibling = nil
+		root = h.link(root, pairs)
+		pairs = next
+	}
+	return root
+}
@@ -0,0 +1,49 @@
+package priorityqueue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPairingHeapMeld(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+	h1 := NewPairingHeap(less)
+	h2 := NewPairingHeap(less)
+	for _, v := range []int{5, 1, 9} {
+		h1.Enqueue(v)
+	}
+	for _, v := range []int{4, 0, 7} {
+		h2.Enqueue(v)
+	}
+
+	h1.Meld(h2)
+	assert.Equal(t, 6, h1.Len(), "Meld should move every element into the receiver")
+	assert.Equal(t, 0, h2.Len(), "Meld should leave the other heap empty")
+
+	var got []int
+	for h1.Len() > 0 {
+		v, _ := h1.Dequeue()
+		got = append(got, v)
+	}
+	assert.Equal(t, []int{0, 1, 4, 5, 7, 9}, got, "Melded elements should dequeue in priority order")
+
+	h1.Meld(h1)
+	h1.Meld(nil)
+	assert.Equal(t, 0, h1.Len(), "Melding with itself or nil should be a no-op")
+}
+
+func TestPairingHeapLongChildList(t *testing.T) {
+	// Ascending inserts into a min-heap build a root with a very long child list,
+	// which the first Dequeue has to merge in pairs.
+	h := NewPairingHeap(func(a, b int) bool { return a < b })
+	for i := 0; i < 100000; i++ {
+		h.Enqueue(i)
+	}
+	for want := 0; want < 100000; want++ {
+		v, _ := h.Dequeue()
+		if !assert.Equal(t, want, v, "Dequeue should handle long child lists") {
+			return
+		}
+	}
+}
@@ -4,15 +4,27 @@ import (
 	"container/heap" // Use the standard import without alias
 )
 
-// PriorityQueue implements a priority queue using a priorityqueue
+// PriorityQueue implements a priority queue using a priorityqueue.
+// Elements the comparator considers equal are dequeued in arbitrary order unless the queue
+// is created with NewStablePriorityQueue, which dequeues them in insertion (FIFO) order.
+// Len, Less, Swap, Push and Pop exist to satisfy container/heap; new code can use Queue, which hides them
+// and offers the same stable mode through NewStableQueue.
 type PriorityQueue[T any] struct {
-	items      []T               // The items in the priorityqueue
-	comparator func(a, b T) bool // Custom comparator function
+	stableItems[T]
 }
 
 // NewPriorityQueue initializes a new priority queue with a custom comparator
 func NewPriorityQueue[T any](comparator func(a, b T) bool) *PriorityQueue[T] {
-	h := &PriorityQueue[T]{comparator: comparator}
+	h := &PriorityQueue[T]{newStableItems[T](nil, comparator, false)}
+	heap.Init(h)
+	return h
+}
+
+// NewStablePriorityQueue initializes a priority queue that dequeues elements of equal priority
+// in the order they were enqueued. Ties are broken by an internal monotonic sequence number,
+// at the cost of an extra comparator call when two elements compare equal.
+func NewStablePriorityQueue[T any](comparator func(a, b T) bool) *PriorityQueue[T] {
+	h := &PriorityQueue[T]{newStableItems[T](nil, comparator, true)}
 	heap.Init(h)
 	return h
 }
@@ -24,26 +36,22 @@ func (h *PriorityQueue[T]) Len() int {
 
 // Less uses the custom comparator function to determine the order
 func (h *PriorityQueue[T]) Less(i, j int) bool {
-	return h.comparator(h.items[i], h.items[j])
+	return h.less(i, j)
 }
 
 // Swap swaps the elements at indices i and j
 func (h *PriorityQueue[T]) Swap(i, j int) {
-	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.swap(i, j)
 }
 
-// Push adds an item to the priorityqueue
+// Push appends an item for container/heap without restoring the heap order. Use Enqueue instead.
 func (h *PriorityQueue[T]) Push(x any) {
-	h.items = append(h.items, x.(T))
+	h.push(x.(T))
 }
 
-// Pop removes and returns the item with the highest priority
+// Pop removes and returns the last item for container/heap. Use Dequeue instead.
 func (h *PriorityQueue[T]) Pop() any {
-	old := h.items
-	n := len(old)
-	item := old[n-1]
-	h.items = old[:n-1]
-	return item
+	return h.pop()
 }
 
 // Peek returns the top element of the priorityqueue without removing it
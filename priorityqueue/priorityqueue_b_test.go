@@ -85,3 +85,209 @@ func BenchmarkPriorityQueuePeek(b *testing.B) {
 		h.Peek()
 	}
 }
+
+func BenchmarkIndexedPriorityQueueUpdate(b *testing.B) {
+	q := NewIndexedPriorityQueue(func(a, b int) bool { return a < b })
+	handles := make([]*Handle[int], 1024)
+	for i := range handles {
+		handles[i] = q.Enqueue(rand.Intn(1 << 20))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q.Update(handles[i%len(handles)], rand.Intn(1<<20))
+	}
+}
+
+func BenchmarkTopKOffer(b *testing.B) {
+	top := NewTopK(100, func(a, b int) bool { return a > b })
+	data := make([]int, b.N)
+	for i := range data {
+		data[i] = rand.Intn(b.N)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		top.Offer(data[i])
+	}
+}
+
+func BenchmarkMinMaxHeapEnqueuePopBothEnds(b *testing.B) {
+	h := NewMinMaxHeap(func(a, b int) bool { return a < b })
+	for i := 0; i < 1024; i++ {
+		h.Enqueue(rand.Intn(1 << 20))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.Enqueue(rand.Intn(1 << 20))
+		if i%2 == 0 {
+			h.PopMin()
+		} else {
+			h.PopMax()
+		}
+	}
+}
+
+func BenchmarkStablePriorityQueueEnqueueDequeue(b *testing.B) {
+	h := NewStablePriorityQueue(func(a, b int) bool { return a < b })
+	for i := 0; i < 1024; i++ {
+		h.Enqueue(rand.Intn(16))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.Enqueue(rand.Intn(16))
+		h.Dequeue()
+	}
+}
+
+// benchmarkHeapEnqueueDequeue measures a steady-state mix of inserts and removals on a 1024 element heap.
+func benchmarkHeapEnqueueDequeue(b *testing.B, h Heap[int]) {
+	for i := 0; i < 1024; i++ {
+		h.Enqueue(rand.Intn(1 << 20))
+	}
+	data := make([]int, b.N)
+	for i := range data {
+		data[i] = rand.Intn(1 << 20)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.Enqueue(data[i])
+		h.Dequeue()
+	}
+}
+
+func BenchmarkHeapEnqueueDequeue(b *testing.B) {
+	less := func(a, b int) bool { return a < b }
+	b.Run("Binary", func(b *testing.B) { benchmarkHeapEnqueueDequeue(b, NewPriorityQueue(less)) })
+	b.Run("Pairing", func(b *testing.B) { benchmarkHeapEnqueueDequeue(b, NewPairingHeap(less)) })
+	b.Run("Leftist", func(b *testing.B) { benchmarkHeapEnqueueDequeue(b, NewLeftistHeap(less)) })
+	b.Run("Queue", func(b *testing.B) { benchmarkHeapEnqueueDequeue(b, NewQueue(less)) })
+}
+
+func BenchmarkHeapMerge(b *testing.B) {
+	const shardSize = 4096
+	less := func(a, b int) bool { return a < b }
+
+	b.Run("Binary", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			dst, src := NewPriorityQueue(less), NewPriorityQueue(less)
+			for j := 0; j < shardSize; j++ {
+				dst.Enqueue(rand.Int())
+				src.Enqueue(rand.Int())
+			}
+			b.StartTimer()
+			for src.Len() > 0 {
+				v, _ := src.Dequeue()
+				dst.Enqueue(v)
+			}
+		}
+	})
+	b.Run("Pairing", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			dst, src := NewPairingHeap(less), NewPairingHeap(less)
+			for j := 0; j < shardSize; j++ {
+				dst.Enqueue(rand.Int())
+				src.Enqueue(rand.Int())
+			}
+			b.StartTimer()
+			dst.Meld(src)
+		}
+	})
+	b.Run("Leftist", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			dst, src := NewLeftistHeap(less), NewLeftistHeap(less)
+			for j := 0; j < shardSize; j++ {
+				dst.Enqueue(rand.Int())
+				src.Enqueue(rand.Int())
+			}
+			b.StartTimer()
+			dst.Meld(src)
+		}
+	})
+}
+
+func BenchmarkNewPriorityQueueFrom(b *testing.B) {
+	data := rand.Perm(4096)
+	less := func(a, b int) bool { return a < b }
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewPriorityQueueFrom(data, less)
+	}
+}
+
+// benchmarkGraph is a random sparse directed graph used by the shortest path benchmarks.
+type benchmarkGraph struct {
+	edges [][]struct{ to, weight int }
+}
+
+// newBenchmarkGraph builds a connected graph with n nodes and degree outgoing edges per node.
+func newBenchmarkGraph(n, degree int) *benchmarkGraph {
+	r := rand.New(rand.NewSource(42))
+	g := &benchmarkGraph{edges: make([][]struct{ to, weight int }, n)}
+	for from := 0; from < n; from++ {
+		g.edges[from] = append(g.edges[from], struct{ to, weight int }{(from + 1) % n, 1 + r.Intn(100)})
+		for i := 1; i < degree; i++ {
+			g.edges[from] = append(g.edges[from], struct{ to, weight int }{r.Intn(n), 1 + r.Intn(100)})
+		}
+	}
+	return g
+}
+
+func BenchmarkDijkstra(b *testing.B) {
+	const nodes, degree = 100000, 8
+	g := newBenchmarkGraph(nodes, degree)
+
+	b.Run("PriorityQueue", func(b *testing.B) {
+		type entry struct{ dist, node int }
+		for i := 0; i < b.N; i++ {
+			dist := make([]int, nodes)
+			for j := range dist {
+				dist[j] = -1
+			}
+			h := NewPriorityQueue(func(a, b entry) bool { return a.dist < b.dist })
+			h.Enqueue(entry{0, 0})
+			for h.Len() > 0 {
+				e, _ := h.Dequeue()
+				if dist[e.node] >= 0 {
+					continue
+				}
+				dist[e.node] = e.dist
+				for _, edge := range g.edges[e.node] {
+					if dist[edge.to] < 0 {
+						h.Enqueue(entry{e.dist + edge.weight, edge.to})
+					}
+				}
+			}
+		}
+	})
+
+	b.Run("RadixHeap", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			dist := make([]int, nodes)
+			for j := range dist {
+				dist[j] = -1
+			}
+			h := NewRadixHeap[int]()
+			_ = h.Push(0, 0)
+			for h.Len() > 0 {
+				d, node, _ := h.Pop()
+				if dist[node] >= 0 {
+					continue
+				}
+				dist[node] = int(d)
+				for _, edge := range g.edges[node] {
+					if dist[edge.to] < 0 {
+						_ = h.Push(d+uint64(edge.weight), edge.to)
+					}
+				}
+			}
+		}
+	})
+}
@@ -148,3 +148,50 @@ func TestPriorityQueueDuplicateElements(t *testing.T) {
 	// Verify the queue is empty
 	assert.Equal(t, 0, h.Len(), "PriorityQueue should be empty after dequeuing all elements")
 }
+
+func TestStablePriorityQueueFIFOAmongEqualPriorities(t *testing.T) {
+	type job struct {
+		priority int
+		name     string
+	}
+	h := NewStablePriorityQueue(func(a, b job) bool {
+		return a.priority < b.priority
+	})
+
+	// Interleave priorities so the heap has to reorder ties
+	for i := 0; i < 20; i++ {
+		h.Enqueue(job{priority: i % 3, name: string(rune('a' + i))})
+	}
+
+	lastSeen := map[int]string{}
+	lastPriority := -1
+	for h.Len() > 0 {
+		j, ok := h.Dequeue()
+		assert.True(t, ok, "Dequeue should return true for a non-empty queue")
+		assert.GreaterOrEqual(t, j.priority, lastPriority, "Jobs should still come out in priority order")
+		assert.Greater(t, j.name, lastSeen[j.priority], "Jobs of equal priority should come out in insertion order")
+		lastSeen[j.priority] = j.name
+		lastPriority = j.priority
+	}
+}
+
+func TestStablePriorityQueueInterleavedOperations(t *testing.T) {
+	type job struct{ priority, id int }
+	h := NewStablePriorityQueue(func(a, b job) bool {
+		return a.priority > b.priority
+	})
+
+	h.Enqueue(job{1, 0})
+	h.Enqueue(job{1, 1})
+	first, _ := h.Dequeue()
+	h.Enqueue(job{1, 2})
+	h.Enqueue(job{2, 3})
+
+	var ids []int
+	for h.Len() > 0 {
+		j, _ := h.Dequeue()
+		ids = append(ids, j.id)
+	}
+	assert.Equal(t, 0, first.id, "The first enqueued job should be dequeued first")
+	assert.Equal(t, []int{3, 1, 2}, ids, "Higher priority first, then ties in insertion order")
+}
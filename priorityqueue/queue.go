@@ -0,0 +1,156 @@
+package priorityqueue
+
+import (
+	"iter"
+	"slices"
+)
+
+// Queue is a binary heap priority queue with a self-contained API. Unlike PriorityQueue it does not
+// export the container/heap plumbing, so elements can only be added through Enqueue and the heap order
+// always holds. Like PriorityQueue, a Queue created by NewStableQueue or NewStableQueueFrom dequeues
+// elements the comparator considers equal in insertion (FIFO) order.
+type Queue[T any] struct {
+	stableItems[T]
+}
+
+// NewQueue initializes an empty Queue with a custom comparator
+func NewQueue[T any](comparator func(a, b T) bool) *Queue[T] {
+	return &Queue[T]{newStableItems[T](nil, comparator, false)}
+}
+
+// NewStableQueue initializes an empty Queue that dequeues elements of equal priority in insertion order
+func NewStableQueue[T any](comparator func(a, b T) bool) *Queue[T] {
+	return &Queue[T]{newStableItems[T](nil, comparator, true)}
+}
+
+// NewStableQueueFrom builds a stable Queue holding a copy of items in O(n). Ties among the initial items
+// are broken by their position in items.
+func NewStableQueueFrom[T any](items []T, comparator func(a, b T) bool) *Queue[T] {
+	return newQueueFrom(items, comparator, true)
+}
+
+// NewPriorityQueueFrom builds a Queue, not a PriorityQueue, holding a copy of items in O(n) with a custom
+// comparator. The result has the same API as PriorityQueue without the container/heap plumbing.
+func NewPriorityQueueFrom[T any](items []T, comparator func(a, b T) bool) *Queue[T] {
+	return newQueueFrom(items, comparator, false)
+}
+
+// newQueueFrom copies items into a new Queue and heapifies it bottom-up.
+func newQueueFrom[T any](items []T, comparator func(a, b T) bool, stable bool) *Queue[T] {
+	q := &Queue[T]{newStableItems(slices.Clone(items), comparator, stable)}
+	for i := len(q.items)/2 - 1; i >= 0; i-- {
+		q.down(i)
+	}
+	return q
+}
+
+// Len returns the number of elements in the queue
+func (q *Queue[T]) Len() int {
+	return len(q.items)
+}
+
+// Peek returns the element with the highest priority without removing it
+func (q *Queue[T]) Peek() (T, bool) {
+	if len(q.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.items[0], true
+}
+
+// Enqueue adds a new element to the queue
+func (q *Queue[T]) Enqueue(value T) {
+	q.push(value)
+	q.up(len(q.items) - 1)
+}
+
+// Dequeue removes and returns the element with the highest priority
+func (q *Queue[T]) Dequeue() (T, bool) {
+	if len(q.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	last := len(q.items) - 1
+	q.swap(0, last)
+	value := q.pop()
+	if last > 0 {
+		q.down(0)
+	}
+	return value, true
+}
+
+// Clear removes every element from the queue, keeping its allocated capacity
+func (q *Queue[T]) Clear() {
+	q.clear()
+}
+
+// Clone returns an independent copy of the queue. Elements themselves are copied by assignment.
+func (q *Queue[T]) Clone() *Queue[T] {
+	return &Queue[T]{q.clone()}
+}
+
+// DrainSorted removes every element and returns them from highest to lowest priority
+func (q *Queue[T]) DrainSorted() []T {
+	sorted := make([]T, 0, len(q.items))
+	for len(q.items) > 0 {
+		value, _ := q.Dequeue()
+		sorted = append(sorted, value)
+	}
+	return sorted
+}
+
+// Items returns an iterator over the elements in no particular order. The queue must not be modified during iteration.
+func (q *Queue[T]) Items() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, value := range q.items {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Sorted returns an iterator over the elements from highest to lowest priority, leaving the queue unchanged.
+// Each step costs O(log n) on a private copy, so stopping early avoids sorting the rest.
+func (q *Queue[T]) Sorted() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		clone := q.Clone()
+		for clone.Len() > 0 {
+			value, _ := clone.Dequeue()
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// up moves the element at i towards the root until its parent has higher priority.
+func (q *Queue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !q.less(i, parent) {
+			return
+		}
+		q.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the element at i towards the leaves until both children have lower priority.
+func (q *Queue[T]) down(i int) {
+	n := len(q.items)
+	for {
+		best := i
+		if left := 2*i + 1; left < n && q.less(left, best) {
+			best = left
+		}
+		if right := 2*i + 2; right < n && q.less(right, best) {
+			best = right
+		}
+		if best == i {
+			return
+		}
+		q.swap(i, best)
+		i = best
+	}
+}
@@ -0,0 +1,95 @@
+package priorityqueue
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPriorityQueueFrom(t *testing.T) {
+	data := rand.New(rand.NewSource(3)).Perm(200)
+	q := NewPriorityQueueFrom(data, func(a, b int) bool { return a < b })
+	assert.Equal(t, len(data), q.Len(), "The queue should hold every initial item")
+
+	data[0] = -1
+	top, _ := q.Peek()
+	assert.Equal(t, 0, top, "The queue should not alias the caller's slice")
+
+	sorted := q.DrainSorted()
+	assert.Equal(t, 0, q.Len(), "DrainSorted should empty the queue")
+	assert.True(t, slices.IsSorted(sorted), "DrainSorted should return elements in priority order")
+	assert.Len(t, sorted, 200, "DrainSorted should return every element")
+}
+
+func TestQueueClearAndClone(t *testing.T) {
+	q := NewPriorityQueueFrom([]int{3, 1, 2}, func(a, b int) bool { return a > b })
+	clone := q.Clone()
+
+	q.Clear()
+	assert.Equal(t, 0, q.Len(), "Clear should remove every element")
+	_, ok := q.Dequeue()
+	assert.False(t, ok, "Dequeue should return false after Clear")
+
+	assert.Equal(t, []int{3, 2, 1}, clone.DrainSorted(), "The clone should be unaffected by Clear")
+
+	q.Enqueue(5)
+	v, _ := q.Peek()
+	assert.Equal(t, 5, v, "The queue should be usable after Clear")
+}
+
+func TestQueueIterators(t *testing.T) {
+	q := NewPriorityQueueFrom([]int{5, 2, 8, 1, 9}, func(a, b int) bool { return a < b })
+
+	items := slices.Collect(q.Items())
+	slices.Sort(items)
+	assert.Equal(t, []int{1, 2, 5, 8, 9}, items, "Items should yield every element")
+
+	assert.Equal(t, []int{1, 2, 5, 8, 9}, slices.Collect(q.Sorted()), "Sorted should yield elements in priority order")
+	assert.Equal(t, 5, q.Len(), "Sorted should not modify the queue")
+
+	var firstTwo []int
+	for v := range q.Sorted() {
+		firstTwo = append(firstTwo, v)
+		if len(firstTwo) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []int{1, 2}, firstTwo, "Sorted should support stopping early")
+}
+
+func TestStableQueueFIFOAmongEqualPriorities(t *testing.T) {
+	type job struct{ priority, id int }
+	byPriority := func(a, b job) bool { return a.priority < b.priority }
+
+	var initial []job
+	for i := 0; i < 10; i++ {
+		initial = append(initial, job{priority: i % 2, id: i})
+	}
+	q := NewStableQueueFrom(initial, byPriority)
+	q.Enqueue(job{priority: 0, id: 10})
+	clone := q.Clone()
+
+	var ids []int
+	for _, j := range q.DrainSorted() {
+		ids = append(ids, j.id)
+	}
+	assert.Equal(t, []int{0, 2, 4, 6, 8, 10, 1, 3, 5, 7, 9}, ids, "Equal priorities should come out in insertion order")
+
+	ids = ids[:0]
+	for j := range clone.Sorted() {
+		ids = append(ids, j.id)
+	}
+	assert.Equal(t, []int{0, 2, 4, 6, 8, 10, 1, 3, 5, 7, 9}, ids, "A clone should keep the stable order")
+
+	empty := NewStableQueue(byPriority)
+	for i := 0; i < 5; i++ {
+		empty.Enqueue(job{id: i})
+	}
+	empty.Clear()
+	empty.Enqueue(job{id: 7})
+	empty.Enqueue(job{id: 8})
+	first, _ := empty.Dequeue()
+	assert.Equal(t, 7, first.id, "Insertion order should hold after Clear")
+}
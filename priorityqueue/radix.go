@@ -0,0 +1,118 @@
+package priorityqueue
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// ErrNonMonotone is returned when pushing a key smaller than the last key popped from a RadixHeap.
+var ErrNonMonotone = errors.New("priorityqueue: key is smaller than the last popped key")
+
+// radixItem is a key and value stored in a RadixHeap bucket.
+type radixItem[V any] struct {
+	key   uint64
+	value V
+}
+
+// RadixHeap is a monotone priority queue keyed by unsigned integers, popping the smallest key first.
+// Keys pushed must never be smaller than the last key popped, which holds for Dijkstra-style workloads.
+// Items are bucketed by the highest bit in which their key differs from the last popped key, so
+// operations cost amortized O(log C) for keys up to C with no comparator calls.
+type RadixHeap[V any] struct {
+	buckets [65][]radixItem[V] // Bucket i holds keys whose highest bit differing from last is bit i-1
+	last    uint64             // Last popped key; every queued key is at least this
+	size    int
+}
+
+// NewRadixHeap initializes an empty radix heap
+func NewRadixHeap[V any]() *RadixHeap[V] {
+	return &RadixHeap[V]{}
+}
+
+// Len returns the number of items in the heap
+func (h *RadixHeap[V]) Len() int {
+	return h.size
+}
+
+// Last returns the last key popped, or zero if nothing has been popped yet
+func (h *RadixHeap[V]) Last() uint64 {
+	return h.last
+}
+
+// Push adds value with the given key. It returns ErrNonMonotone if key is smaller than the last popped key.
+func (h *RadixHeap[V]) Push(key uint64, value V) error {
+	if key < h.last {
+		return ErrNonMonotone
+	}
+	b := bits.Len64(key ^ h.last)
+	h.buckets[b] = append(h.buckets[b], radixItem[V]{key: key, value: value})
+	h.size++
+	return nil
+}
+
+// Peek returns the smallest key and its value without removing them. It does not advance the last
+// popped key, so it scans the lowest non-empty bucket instead of redistributing it.
+func (h *RadixHeap[V]) Peek() (uint64, V, bool) {
+	if h.size == 0 {
+		var zero V
+		return 0, zero, false
+	}
+	i := 0
+	for len(h.buckets[i]) == 0 {
+		i++
+	}
+	best := h.buckets[i][len(h.buckets[i])-1]
+	if i > 0 {
+		for _, item := range h.buckets[i] {
+			if item.key < best.key {
+				best = item
+			}
+		}
+	}
+	return best.key, best.value, true
+}
+
+// Pop removes and returns the smallest key and its value
+func (h *RadixHeap[V]) Pop() (uint64, V, bool) {
+	if !h.fill() {
+		var zero V
+		return 0, zero, false
+	}
+	bucket := h.buckets[0]
+	item := bucket[len(bucket)-1]
+	bucket[len(bucket)-1] = radixItem[V]{} // Avoid retaining the value
+	h.buckets[0] = bucket[:len(bucket)-1]
+	h.size--
+	return item.key, item.value, true
+}
+
+// fill ensures bucket 0, which holds the keys equal to last, is non-empty by advancing last to the
+// smallest queued key and redistributing its bucket. It returns false if the heap is empty.
+func (h *RadixHeap[V]) fill() bool {
+	if h.size == 0 {
+		return false
+	}
+	if len(h.buckets[0]) > 0 {
+		return true
+	}
+
+	i := 1
+	for len(h.buckets[i]) == 0 {
+		i++
+	}
+	bucket := h.buckets[i]
+	minKey := bucket[0].key
+	for _, item := range bucket[1:] {
+		minKey = min(minKey, item.key)
+	}
+
+	// Every key in bucket i now differs from the new last in a lower bit, so each moves to a lower bucket.
+	h.last = minKey
+	for _, item := range bucket {
+		b := bits.Len64(item.key ^ h.last)
+		h.buckets[b] = append(h.buckets[b], item)
+	}
+	clear(bucket)
+	h.buckets[i] = bucket[:0]
+	return true
+}
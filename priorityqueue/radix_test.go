@@ -0,0 +1,95 @@
+package priorityqueue
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRadixHeapBasicOperations(t *testing.T) {
+	h := NewRadixHeap[string]()
+	_, _, ok := h.Pop()
+	assert.False(t, ok, "Pop should return false for an empty heap")
+
+	assert.NoError(t, h.Push(5, "five"), "Push should accept any key on a fresh heap")
+	assert.NoError(t, h.Push(1, "one"), "Push should accept any key on a fresh heap")
+	assert.NoError(t, h.Push(3, "three"), "Push should accept any key on a fresh heap")
+	assert.Equal(t, 3, h.Len(), "Heap length should be 3")
+
+	key, value, ok := h.Peek()
+	assert.True(t, ok, "Peek should return true for a non-empty heap")
+	assert.Equal(t, uint64(1), key, "Peek should return the smallest key")
+	assert.Equal(t, "one", value, "Peek should return the value of the smallest key")
+	assert.Equal(t, 3, h.Len(), "Peek should not remove the item")
+
+	key, value, _ = h.Pop()
+	assert.Equal(t, uint64(1), key, "Pop should return the smallest key")
+	assert.Equal(t, "one", value, "Pop should return the value of the smallest key")
+	assert.Equal(t, uint64(1), h.Last(), "Last should report the popped key")
+
+	assert.NoError(t, h.Push(1, "one again"), "Push should accept a key equal to the last popped key")
+	assert.ErrorIs(t, h.Push(0, "zero"), ErrNonMonotone, "Push should reject a key below the last popped key")
+
+	var keys []uint64
+	for h.Len() > 0 {
+		key, _, _ := h.Pop()
+		keys = append(keys, key)
+	}
+	assert.Equal(t, []uint64{1, 3, 5}, keys, "Remaining keys should be popped in ascending order")
+}
+
+func TestRadixHeapExtremeKeys(t *testing.T) {
+	h := NewRadixHeap[int]()
+	_ = h.Push(math.MaxUint64, 2)
+	_ = h.Push(0, 0)
+	_ = h.Push(1<<63, 1)
+
+	for want := 0; want < 3; want++ {
+		_, value, ok := h.Pop()
+		assert.True(t, ok, "Pop should return true while items remain")
+		assert.Equal(t, want, value, "Keys across the full range should be popped in order")
+	}
+}
+
+func TestRadixHeapRandomizedMonotone(t *testing.T) {
+	h := NewRadixHeap[uint64]()
+	var model []uint64
+	r := rand.New(rand.NewSource(4))
+
+	for i := 0; i < 5000; i++ {
+		if r.Intn(3) > 0 || len(model) == 0 {
+			key := h.Last() + uint64(r.Intn(1000))
+			assert.NoError(t, h.Push(key, key), "Push should accept keys at or above the last popped key")
+			model = append(model, key)
+			continue
+		}
+		want := slices.Min(model)
+		key, value, ok := h.Pop()
+		assert.True(t, ok, "Pop should return true for a non-empty heap")
+		assert.Equal(t, want, key, "Pop should return the smallest key")
+		assert.Equal(t, key, value, "Pop should return the value pushed with the key")
+		idx := slices.Index(model, key)
+		model = slices.Delete(model, idx, idx+1)
+	}
+	assert.Equal(t, len(model), h.Len(), "Len should match the number of queued items")
+}
+
+func TestRadixHeapPushAfterPeek(t *testing.T) {
+	h := NewRadixHeap[string]()
+	_ = h.Push(10, "ten")
+
+	key, _, _ := h.Peek()
+	assert.Equal(t, uint64(10), key, "Peek should return the smallest key")
+	assert.Equal(t, uint64(0), h.Last(), "Peek should not change the last popped key")
+	assert.NoError(t, h.Push(5, "five"), "Peek should not make smaller keys non-monotone")
+
+	key, value, _ := h.Pop()
+	assert.Equal(t, uint64(5), key, "Pop should return the key pushed after Peek")
+	assert.Equal(t, "five", value)
+	key, _, _ = h.Peek()
+	assert.Equal(t, uint64(10), key, "Peek should see the remaining key")
+	assert.ErrorIs(t, h.Push(4, "four"), ErrNonMonotone, "Push should still reject keys below the last popped key")
+}
@@ -0,0 +1,83 @@
+package priorityqueue
+
+import "slices"
+
+// stableItems holds the elements of a binary heap together with the insertion sequence numbers used to
+// break ties in stable mode. Queue and PriorityQueue embed it so both order equal elements the same way.
+type stableItems[T any] struct {
+	items      []T               // The items in heap order
+	comparator func(a, b T) bool // Custom comparator function
+	stable     bool              // Whether ties are broken by insertion order
+	seqs       []uint64          // Insertion sequence of each item, kept in step with items in stable mode
+	nextSeq    uint64            // Sequence number given to the next inserted item
+}
+
+// newStableItems takes ownership of items, numbering them by position in stable mode.
+func newStableItems[T any](items []T, comparator func(a, b T) bool, stable bool) stableItems[T] {
+	s := stableItems[T]{items: items, comparator: comparator, stable: stable}
+	if stable {
+		s.seqs = make([]uint64, len(items))
+		for i := range s.seqs {
+			s.seqs[i] = uint64(i)
+		}
+		s.nextSeq = uint64(len(items))
+	}
+	return s
+}
+
+// less reports whether the element at i has higher priority than the element at j,
+// falling back to insertion order for ties in stable mode.
+func (s *stableItems[T]) less(i, j int) bool {
+	if s.comparator(s.items[i], s.items[j]) {
+		return true
+	}
+	if !s.stable || s.comparator(s.items[j], s.items[i]) {
+		return false
+	}
+	return s.seqs[i] < s.seqs[j] // Equal priority: the earlier insertion wins
+}
+
+// swap swaps the elements at indices i and j
+func (s *stableItems[T]) swap(i, j int) {
+	s.items[i], s.items[j] = s.items[j], s.items[i]
+	if s.stable {
+		s.seqs[i], s.seqs[j] = s.seqs[j], s.seqs[i]
+	}
+}
+
+// push appends value with the next sequence number, without restoring the heap order.
+func (s *stableItems[T]) push(value T) {
+	s.items = append(s.items, value)
+	if s.stable {
+		s.seqs = append(s.seqs, s.nextSeq)
+		s.nextSeq++
+	}
+}
+
+// pop removes and returns the last element, without restoring the heap order.
+func (s *stableItems[T]) pop() T {
+	var zero T
+	last := len(s.items) - 1
+	value := s.items[last]
+	s.items[last] = zero // Avoid retaining the removed element
+	s.items = s.items[:last]
+	if s.stable {
+		s.seqs = s.seqs[:last]
+	}
+	return value
+}
+
+// clear removes every element, keeping the allocated capacity.
+func (s *stableItems[T]) clear() {
+	clear(s.items)
+	s.items = s.items[:0]
+	s.seqs = s.seqs[:0]
+}
+
+// clone returns an independent copy. Elements themselves are copied by assignment.
+func (s *stableItems[T]) clone() stableItems[T] {
+	c := *s
+	c.items = slices.Clone(s.items)
+	c.seqs = slices.Clone(s.seqs)
+	return c
+}
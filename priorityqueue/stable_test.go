@@ -0,0 +1,35 @@
+package priorityqueue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStableItemsTieBreak(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+
+	s := newStableItems([]int{5, 5}, less, true)
+	s.push(5)
+	assert.True(t, s.less(0, 1), "Among equal elements the earlier insertion should win")
+	assert.False(t, s.less(2, 0))
+	s.swap(0, 2)
+	assert.True(t, s.less(2, 0), "Sequence numbers should move with their elements")
+	assert.Equal(t, 5, s.pop())
+	assert.Equal(t, []uint64{2, 1}, s.seqs, "pop should drop the sequence number of the removed element")
+
+	unstable := newStableItems([]int{5, 5}, less, false)
+	assert.False(t, unstable.less(0, 1), "Without stable mode equal elements should not be ordered")
+	assert.Nil(t, unstable.seqs, "Sequence numbers should only be kept in stable mode")
+}
+
+func TestStableItemsClone(t *testing.T) {
+	s := newStableItems([]int{1, 2}, func(a, b int) bool { return a < b }, true)
+	c := s.clone()
+	c.push(3)
+	c.swap(0, 1)
+
+	assert.Equal(t, []int{1, 2}, s.items, "A clone should not share items with the original")
+	assert.Equal(t, []uint64{0, 1}, s.seqs, "A clone should not share sequence numbers with the original")
+	assert.Equal(t, uint64(2), s.nextSeq)
+}
@@ -0,0 +1,78 @@
+package priorityqueue
+
+import (
+	"container/heap"
+	"slices"
+)
+
+// TopK keeps the best k elements offered to it, where comparator(a, b) reports whether a ranks better than b.
+// Internally it is a heap with the worst kept element on top, so each Offer costs O(log k).
+type TopK[T any] struct {
+	k          int
+	comparator func(a, b T) bool // Reports whether a ranks better than b
+	worst      *PriorityQueue[T] // Heap ordered so the worst kept element is on top
+}
+
+// NewTopK initializes a TopK that keeps at most k elements ranked by comparator
+func NewTopK[T any](k int, comparator func(a, b T) bool) *TopK[T] {
+	return &TopK[T]{
+		k:          max(k, 0),
+		comparator: comparator,
+		worst:      NewPriorityQueue(func(a, b T) bool { return comparator(b, a) }),
+	}
+}
+
+// Len returns the number of elements kept
+func (t *TopK[T]) Len() int {
+	return t.worst.Len()
+}
+
+// K returns the maximum number of elements kept
+func (t *TopK[T]) K() int {
+	return t.k
+}
+
+// Offer adds value if there is room or if it ranks better than the worst kept element, which it then evicts.
+// It returns true if value was kept.
+func (t *TopK[T]) Offer(value T) bool {
+	if t.worst.Len() < t.k {
+		t.worst.Enqueue(value)
+		return true
+	}
+	if t.k == 0 || !t.comparator(value, t.worst.items[0]) {
+		return false
+	}
+	t.worst.items[0] = value
+	heap.Fix(t.worst, 0)
+	return true
+}
+
+// Worst returns the lowest ranked element kept, which is the next one to be evicted
+func (t *TopK[T]) Worst() (T, bool) {
+	return t.worst.Peek()
+}
+
+// Sorted returns the kept elements from best to worst
+func (t *TopK[T]) Sorted() []T {
+	sorted := slices.Clone(t.worst.items)
+	slices.SortStableFunc(sorted, func(a, b T) int {
+		switch {
+		case t.comparator(a, b):
+			return -1
+		case t.comparator(b, a):
+			return 1
+		default:
+			return 0
+		}
+	})
+	return sorted
+}
+
+// Merge offers every element kept by others to t, combining top-k results computed separately.
+func (t *TopK[T]) Merge(others ...*TopK[T]) {
+	for _, other := range others {
+		for _, value := range other.worst.items {
+			t.Offer(value)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package priorityqueue
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopKKeepsBest(t *testing.T) {
+	top := NewTopK(3, func(a, b int) bool { return a > b })
+	for _, v := range []int{5, 1, 9, 3, 7, 2, 8} {
+		top.Offer(v)
+	}
+
+	assert.Equal(t, 3, top.Len(), "TopK should keep at most k elements")
+	assert.Equal(t, []int{9, 8, 7}, top.Sorted(), "Sorted should return the best elements in rank order")
+
+	worst, ok := top.Worst()
+	assert.True(t, ok, "Worst should return true when elements are kept")
+	assert.Equal(t, 7, worst, "Worst should return the lowest ranked kept element")
+}
+
+func TestTopKOfferResult(t *testing.T) {
+	top := NewTopK(2, func(a, b int) bool { return a < b })
+	assert.True(t, top.Offer(5), "Offer should keep elements while there is room")
+	assert.True(t, top.Offer(3), "Offer should keep elements while there is room")
+	assert.False(t, top.Offer(6), "Offer should reject an element worse than all kept ones")
+	assert.False(t, top.Offer(5), "Offer should reject an element that only ties the worst")
+	assert.True(t, top.Offer(1), "Offer should keep an element better than the worst")
+	assert.Equal(t, []int{1, 3}, top.Sorted(), "The worst element should have been evicted")
+}
+
+func TestTopKZero(t *testing.T) {
+	top := NewTopK(0, func(a, b int) bool { return a > b })
+	assert.False(t, top.Offer(1), "A TopK with k of zero should keep nothing")
+	assert.Empty(t, top.Sorted(), "Sorted should be empty when nothing is kept")
+	_, ok := top.Worst()
+	assert.False(t, ok, "Worst should return false when nothing is kept")
+}
+
+func TestTopKMerge(t *testing.T) {
+	better := func(a, b int) bool { return a > b }
+	data := rand.Perm(1000)
+
+	shards := make([]*TopK[int], 4)
+	for i := range shards {
+		shards[i] = NewTopK(10, better)
+	}
+	for i, v := range data {
+		shards[i%len(shards)].Offer(v)
+	}
+
+	merged := NewTopK(10, better)
+	merged.Merge(shards...)
+
+	slices.Sort(data)
+	slices.Reverse(data)
+	assert.Equal(t, data[:10], merged.Sorted(), "Merging shards should give the overall top k")
+}
@@ -0,0 +1,155 @@
+package queue
+
+import (
+	"context"
+	"runtime"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	mpmcSpins      = 16                     // Attempts before a blocking call starts yielding
+	mpmcMaxBackoff = 500 * time.Microsecond // Upper bound on the sleep between attempts
+)
+
+// cacheLinePad keeps hot counters on separate cache lines.
+type cacheLinePad [64]byte
+
+// mpmcCell is a slot of the ring. Its sequence number tells producers and consumers whose turn it is.
+type mpmcCell[T any] struct {
+	seq   atomic.Uint64
+	value T
+}
+
+// MPMCQueue is a bounded lock-free queue safe for any number of concurrent producers and consumers.
+// It follows Dmitry Vyukov's design: each slot carries a sequence number, so an operation claims a slot
+// with a single compare-and-swap on the shared position.
+type MPMCQueue[T any] struct {
+	_       cacheLinePad
+	enqueue atomic.Uint64 // Next position to write
+	_       cacheLinePad
+	dequeue atomic.Uint64 // Next position to read
+	_       cacheLinePad
+	mask    uint64
+	cells   []mpmcCell[T]
+}
+
+// NewMPMCQueue creates an MPMCQueue holding at least capacity elements, rounded up to a power of two.
+func NewMPMCQueue[T any](capacity int) *MPMCQueue[T] {
+	size := 2
+	for size < capacity {
+		size <<= 1
+	}
+	q := &MPMCQueue[T]{mask: uint64(size - 1), cells: make([]mpmcCell[T], size)}
+	for i := range q.cells {
+		q.cells[i].seq.Store(uint64(i))
+	}
+	return q
+}
+
+// TryEnqueue adds value to the queue without blocking. It returns false if the queue is full.
+func (q *MPMCQueue[T]) TryEnqueue(value T) bool {
+	pos := q.enqueue.Load()
+	for {
+		cell := &q.cells[pos&q.mask]
+		diff := int64(cell.seq.Load() - pos)
+		switch {
+		case diff == 0:
+			if q.enqueue.CompareAndSwap(pos, pos+1) {
+				cell.value = value
+				cell.seq.Store(pos + 1)
+				return true
+			}
+			pos = q.enqueue.Load()
+		case diff < 0:
+			return false // The slot still holds a value from the previous lap
+		default:
+			pos = q.enqueue.Load() // Another producer claimed the slot
+		}
+	}
+}
+
+// TryDequeue removes and returns the oldest value without blocking. It returns false if the queue is empty.
+func (q *MPMCQueue[T]) TryDequeue() (T, bool) {
+	pos := q.dequeue.Load()
+	for {
+		cell := &q.cells[pos&q.mask]
+		diff := int64(cell.seq.Load() - (pos + 1))
+		switch {
+		case diff == 0:
+			if q.dequeue.CompareAndSwap(pos, pos+1) {
+				value := cell.value
+				var zero T
+				cell.value = zero
+				cell.seq.Store(pos + q.mask + 1)
+				return value, true
+			}
+			pos = q.dequeue.Load()
+		case diff < 0:
+			var zero T
+			return zero, false // The slot has not been written yet
+		default:
+			pos = q.dequeue.Load() // Another consumer claimed the slot
+		}
+	}
+}
+
+// Enqueue adds value to the queue, backing off while it is full until there is room or ctx is done.
+func (q *MPMCQueue[T]) Enqueue(ctx context.Context, value T) error {
+	var b backoff
+	for !q.TryEnqueue(value) {
+		if err := b.wait(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Dequeue removes and returns the oldest value, backing off while the queue is empty until a value
+// arrives or ctx is done.
+func (q *MPMCQueue[T]) Dequeue(ctx context.Context) (T, error) {
+	var b backoff
+	for {
+		if value, ok := q.TryDequeue(); ok {
+			return value, nil
+		}
+		if err := b.wait(ctx); err != nil {
+			var zero T
+			return zero, err
+		}
+	}
+}
+
+// Len returns an approximation of the number of queued values.
+func (q *MPMCQueue[T]) Len() int {
+	n := int64(q.enqueue.Load() - q.dequeue.Load())
+	return int(max(0, min(n, int64(len(q.cells)))))
+}
+
+// Cap returns the capacity of the queue.
+func (q *MPMCQueue[T]) Cap() int {
+	return len(q.cells)
+}
+
+// backoff spins, then yields, then sleeps for exponentially longer periods.
+type backoff struct {
+	attempt int
+	sleep   time.Duration
+}
+
+// wait pauses before the next attempt. It returns ctx.Err() once ctx is done.
+func (b *backoff) wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b.attempt++
+	switch {
+	case b.attempt <= mpmcSpins:
+	case b.attempt <= 2*mpmcSpins:
+		runtime.Gosched()
+	default:
+		b.sleep = min(max(2*b.sleep, time.Microsecond), mpmcMaxBackoff)
+		time.Sleep(b.sleep)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package queue
+
+import (
+	"context"
+	"testing"
+)
+
+func BenchmarkMPMCQueueParallel(b *testing.B) {
+	q := NewMPMCQueue[int](1024)
+	ctx := context.Background()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = q.Enqueue(ctx, 1)
+			_, _ = q.Dequeue(ctx)
+		}
+	})
+}
+
+func BenchmarkChannelParallel(b *testing.B) {
+	ch := make(chan int, 1024)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			ch <- 1
+			<-ch
+		}
+	})
+}
+
+func BenchmarkMPMCQueueSingle(b *testing.B) {
+	q := NewMPMCQueue[int](1024)
+	for i := 0; i < b.N; i++ {
+		q.TryEnqueue(i)
+		q.TryDequeue()
+	}
+}
+
+func BenchmarkChannelSingle(b *testing.B) {
+	ch := make(chan int, 1024)
+	for i := 0; i < b.N; i++ {
+		ch <- i
+		<-ch
+	}
+}
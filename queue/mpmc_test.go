@@ -0,0 +1,111 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMPMCQueueRoundsCapacity(t *testing.T) {
+	assert.Equal(t, 8, NewMPMCQueue[int](5).Cap(), "Capacity should round up to a power of two")
+	assert.Equal(t, 8, NewMPMCQueue[int](8).Cap(), "A power of two capacity should be kept")
+	assert.Equal(t, 2, NewMPMCQueue[int](0).Cap(), "Capacity should be at least two")
+}
+
+func TestMPMCQueueTryEnqueueAndDequeue(t *testing.T) {
+	q := NewMPMCQueue[int](4)
+
+	_, ok := q.TryDequeue()
+	assert.False(t, ok, "TryDequeue should fail on an empty queue")
+
+	for i := 1; i <= 4; i++ {
+		assert.True(t, q.TryEnqueue(i), "TryEnqueue should succeed while there is room")
+	}
+	assert.False(t, q.TryEnqueue(5), "TryEnqueue should fail on a full queue")
+	assert.Equal(t, 4, q.Len(), "Len should report the queued values")
+
+	for i := 1; i <= 4; i++ {
+		v, ok := q.TryDequeue()
+		assert.True(t, ok, "TryDequeue should succeed while values are queued")
+		assert.Equal(t, i, v, "Values should come out in FIFO order")
+	}
+	assert.Equal(t, 0, q.Len(), "Len should be zero once drained")
+}
+
+func TestMPMCQueueWrapsAround(t *testing.T) {
+	q := NewMPMCQueue[int](2)
+	for i := 0; i < 10; i++ {
+		assert.True(t, q.TryEnqueue(i), "TryEnqueue should reuse freed slots")
+		v, ok := q.TryDequeue()
+		assert.True(t, ok, "TryDequeue should see the value just added")
+		assert.Equal(t, i, v, "Values should survive wrapping around the ring")
+	}
+}
+
+func TestMPMCQueueBlockingRespectsContext(t *testing.T) {
+	q := NewMPMCQueue[int](2)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := q.Dequeue(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Dequeue should give up when the context expires")
+
+	assert.NoError(t, q.Enqueue(context.Background(), 1), "Enqueue should succeed while there is room")
+	assert.NoError(t, q.Enqueue(context.Background(), 2), "Enqueue should succeed while there is room")
+	assert.ErrorIs(t, q.Enqueue(ctx, 3), context.DeadlineExceeded, "Enqueue should give up when the context expires")
+}
+
+func TestMPMCQueueBlockingWaitsForRoom(t *testing.T) {
+	q := NewMPMCQueue[int](2)
+	q.TryEnqueue(1)
+	q.TryEnqueue(2)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		q.TryDequeue()
+	}()
+
+	assert.NoError(t, q.Enqueue(context.Background(), 3), "Enqueue should succeed once a consumer frees a slot")
+}
+
+func TestMPMCQueueConcurrentProducersAndConsumers(t *testing.T) {
+	const producers, consumers, perProducer = 4, 4, 5000
+	q := NewMPMCQueue[int](64)
+	ctx := context.Background()
+
+	var producing sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		producing.Add(1)
+		go func(p int) {
+			defer producing.Done()
+			for i := 0; i < perProducer; i++ {
+				_ = q.Enqueue(ctx, p*perProducer+i)
+			}
+		}(p)
+	}
+
+	results := make(chan []int, consumers)
+	for c := 0; c < consumers; c++ {
+		go func() {
+			var got []int
+			for i := 0; i < producers*perProducer/consumers; i++ {
+				v, _ := q.Dequeue(ctx)
+				got = append(got, v)
+			}
+			results <- got
+		}()
+	}
+	producing.Wait()
+
+	seen := make(map[int]bool, producers*perProducer)
+	for c := 0; c < consumers; c++ {
+		for _, v := range <-results {
+			assert.False(t, seen[v], "Each value should be dequeued exactly once")
+			seen[v] = true
+		}
+	}
+	assert.Len(t, seen, producers*perProducer, "Every enqueued value should be dequeued")
+}
@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// GCRA is a Limiter implementing the generic cell rate algorithm.
+// It behaves like a token bucket but stores only a single theoretical arrival time.
+type GCRA struct {
+	mu       sync.Mutex
+	interval time.Duration // Emission interval between events at the sustained rate; 0 when the rate is not positive
+	burst    int           // Number of events that may happen back to back
+	left     int           // Events still admitted when the rate is not positive
+	tat      time.Time     // Theoretical arrival time of the next event
+	now      func() time.Time
+}
+
+// NewGCRA creates a GCRA limiter admitting rate events per second with bursts of up to burst events.
+// A burst below 1 is treated as 1. Like TokenBucket, a rate of zero or less admits only the initial burst.
+func NewGCRA(rate float64, burst int) *GCRA {
+	g := &GCRA{burst: max(burst, 1), now: time.Now}
+	if rate > 0 {
+		g.interval = time.Duration(float64(time.Second) / rate)
+	} else {
+		g.left = g.burst
+	}
+	return g
+}
+
+// Allow reports whether one event may happen now, consuming it if so.
+func (g *GCRA) Allow() bool {
+	return g.reserve(1, false).OK
+}
+
+// Wait blocks until one event may happen or ctx is done.
+func (g *GCRA) Wait(ctx context.Context) error {
+	return wait(ctx, g)
+}
+
+// Reserve reserves n events and returns how long to wait before they may happen.
+func (g *GCRA) Reserve(n int) Reservation {
+	return g.reserve(n, true)
+}
+
+// reserve reserves n events. If mayDelay is false, events are only reserved when they may happen now.
+func (g *GCRA) reserve(n int, mayDelay bool) Reservation {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if n > g.burst {
+		return Reservation{}
+	}
+	if g.interval <= 0 {
+		return g.reserveBurst(n)
+	}
+
+	now := g.now()
+	increment := time.Duration(n) * g.interval
+	tat := maxTime(g.tat, now).Add(increment)
+	allowAt := tat.Add(-time.Duration(g.burst) * g.interval)
+	delay := allowAt.Sub(now)
+	if delay < 0 {
+		delay = 0
+	}
+	if delay > 0 && !mayDelay {
+		return Reservation{}
+	}
+	g.tat = tat
+
+	return Reservation{OK: true, Delay: delay, cancel: func() {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		g.tat = g.tat.Add(-increment)
+	}}
+}
+
+// reserveBurst reserves n of the events left when the rate is not positive. The caller must hold g.mu.
+func (g *GCRA) reserveBurst(n int) Reservation {
+	if n > g.left {
+		return Reservation{}
+	}
+	g.left -= n
+	return Reservation{OK: true, cancel: func() {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		g.left += n
+	}}
+}
@@ -0,0 +1,54 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGCRABurstAndRate(t *testing.T) {
+	clock := newFakeClock()
+	g := NewGCRA(10, 3)
+	g.now = clock.Now
+
+	for i := 0; i < 3; i++ {
+		assert.True(t, g.Allow(), "GCRA should allow the burst")
+	}
+	assert.False(t, g.Allow(), "GCRA should reject past the burst")
+
+	clock.Advance(100 * time.Millisecond)
+	assert.True(t, g.Allow(), "GCRA should allow one event per emission interval")
+	assert.False(t, g.Allow(), "GCRA should not allow two events in one interval")
+}
+
+func TestGCRAReserve(t *testing.T) {
+	clock := newFakeClock()
+	g := NewGCRA(10, 2)
+	g.now = clock.Now
+
+	assert.Equal(t, time.Duration(0), g.Reserve(2).Delay, "Burst should be reserved without delay")
+
+	r := g.Reserve(1)
+	assert.True(t, r.OK)
+	assert.Equal(t, 100*time.Millisecond, r.Delay, "Next event should wait one emission interval")
+
+	r.Cancel()
+	assert.False(t, g.Allow(), "Cancelling should not grant capacity beyond the rate")
+	clock.Advance(100 * time.Millisecond)
+	assert.True(t, g.Allow(), "Cancelled reservation should free its slot")
+}
+
+func TestGCRAZeroRate(t *testing.T) {
+	g := NewGCRA(0, 2)
+
+	assert.True(t, g.Allow(), "A zero rate should still admit the initial burst")
+	r := g.Reserve(1)
+	assert.True(t, r.OK, "A zero rate should still admit the initial burst")
+	assert.False(t, g.Allow(), "A zero rate should never admit more than the burst")
+
+	r.Cancel()
+	assert.True(t, g.Allow(), "Cancelling should return the event to the burst")
+	assert.ErrorIs(t, g.Wait(context.Background()), ErrExceedsLimit, "Wait should fail once the burst is spent")
+}
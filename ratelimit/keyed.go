@@ -0,0 +1,94 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Keyed maintains an independent Limiter per key, such as per user or per API token.
+// Limiters that have not been used for at least the idle timeout are evicted during a later access.
+type Keyed[K comparable] struct {
+	mu        sync.Mutex
+	factory   func() Limiter // Creates the limiter for a new key
+	idle      time.Duration  // Time after which an unused limiter is evicted
+	entries   map[K]*keyedEntry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// keyedEntry is a limiter together with the time it was last used.
+type keyedEntry struct {
+	limiter  Limiter
+	lastUsed time.Time
+}
+
+// NewKeyed creates a Keyed limiter that builds a limiter per key with factory and evicts limiters idle for longer than idle.
+// An idle timeout of zero disables eviction.
+func NewKeyed[K comparable](factory func() Limiter, idle time.Duration) *Keyed[K] {
+	return &Keyed[K]{
+		factory: factory,
+		idle:    idle,
+		entries: make(map[K]*keyedEntry),
+		now:     time.Now,
+	}
+}
+
+// Get returns the limiter for key, creating it if necessary.
+func (k *Keyed[K]) Get(key K) Limiter {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	now := k.now()
+	k.sweep(now)
+	entry, ok := k.entries[key]
+	if !ok {
+		entry = &keyedEntry{limiter: k.factory()}
+		k.entries[key] = entry
+	}
+	entry.lastUsed = now
+	return entry.limiter
+}
+
+// Allow reports whether one event for key may happen now, consuming it if so.
+func (k *Keyed[K]) Allow(key K) bool {
+	return k.Get(key).Allow()
+}
+
+// Wait blocks until one event for key may happen or ctx is done.
+func (k *Keyed[K]) Wait(ctx context.Context, key K) error {
+	return k.Get(key).Wait(ctx)
+}
+
+// Reserve reserves n events for key and returns how long to wait before they may happen.
+func (k *Keyed[K]) Reserve(key K, n int) Reservation {
+	return k.Get(key).Reserve(n)
+}
+
+// Len returns the number of keys with a live limiter.
+func (k *Keyed[K]) Len() int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return len(k.entries)
+}
+
+// Evict removes idle limiters immediately instead of waiting for the next access.
+func (k *Keyed[K]) Evict() {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.lastSweep = time.Time{}
+	k.sweep(k.now())
+}
+
+// sweep removes idle limiters, at most once per idle timeout.
+func (k *Keyed[K]) sweep(now time.Time) {
+	if k.idle <= 0 || now.Sub(k.lastSweep) < k.idle {
+		return
+	}
+	k.lastSweep = now
+	for key, entry := range k.entries {
+		if now.Sub(entry.lastUsed) >= k.idle {
+			delete(k.entries, key)
+		}
+	}
+}
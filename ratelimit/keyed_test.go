@@ -0,0 +1,49 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyedIndependentLimiters(t *testing.T) {
+	k := NewKeyed[string](func() Limiter { return NewTokenBucket(1, 1) }, time.Minute)
+
+	assert.True(t, k.Allow("a"))
+	assert.False(t, k.Allow("a"), "Key a should be limited")
+	assert.True(t, k.Allow("b"), "Key b should have its own limiter")
+	assert.True(t, k.Reserve("c", 1).OK)
+	assert.NoError(t, k.Wait(context.Background(), "d"))
+	assert.Equal(t, 4, k.Len())
+}
+
+func TestKeyedIdleEviction(t *testing.T) {
+	clock := newFakeClock()
+	k := NewKeyed[string](func() Limiter { return NewTokenBucket(1, 1) }, time.Minute)
+	k.now = clock.Now
+
+	k.Get("a")
+	clock.Advance(30 * time.Second)
+	k.Get("b")
+	clock.Advance(40 * time.Second)
+
+	k.Evict()
+	assert.Equal(t, 1, k.Len(), "Only the idle limiter should be evicted")
+
+	clock.Advance(2 * time.Minute)
+	assert.True(t, k.Allow("a"), "Evicted key should get a fresh limiter")
+	assert.Equal(t, 1, k.Len(), "Access should sweep other idle limiters")
+}
+
+func TestKeyedNoEviction(t *testing.T) {
+	clock := newFakeClock()
+	k := NewKeyed[int](func() Limiter { return NewGCRA(1, 1) }, 0)
+	k.now = clock.Now
+
+	k.Get(1)
+	clock.Advance(time.Hour)
+	k.Evict()
+	assert.Equal(t, 1, k.Len(), "A zero idle timeout should disable eviction")
+}
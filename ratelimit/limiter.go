@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrExceedsLimit is returned when a request asks for more events than the limiter can ever admit at once.
+var ErrExceedsLimit = errors.New("ratelimit: request exceeds limiter capacity")
+
+// Limiter is the common interface of all rate limiting algorithms in this package.
+type Limiter interface {
+	// Allow reports whether one event may happen now, consuming it if so.
+	Allow() bool
+	// Wait blocks until one event may happen or ctx is done.
+	Wait(ctx context.Context) error
+	// Reserve reserves n events and reports how long the caller must wait before they may happen.
+	Reserve(n int) Reservation
+}
+
+// Reservation is the result of Limiter.Reserve.
+type Reservation struct {
+	OK     bool          // False if the request can never be satisfied; nothing was reserved
+	Delay  time.Duration // Time to wait before acting on the reservation
+	cancel func()        // Returns the reserved events to the limiter
+}
+
+// Cancel returns the reserved events to the limiter, as far as the algorithm allows.
+// It should only be called by callers that have not acted on the reservation.
+func (r Reservation) Cancel() {
+	if r.OK && r.cancel != nil {
+		r.cancel()
+	}
+}
+
+// wait reserves a single event on l and sleeps for the reservation delay, cancelling it if ctx is done first.
+func wait(ctx context.Context, l Limiter) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r := l.Reserve(1)
+	if !r.OK {
+		return ErrExceedsLimit
+	}
+	if r.Delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(r.Delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		r.Cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// maxTime returns the later of a and b.
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
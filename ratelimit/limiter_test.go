@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock is a manually advanced time source for tests.
+type fakeClock struct {
+	t time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{t: time.Unix(1_700_000_000, 0)}
+}
+
+func (c *fakeClock) Now() time.Time { return c.t }
+
+func (c *fakeClock) Advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func TestLimitersSatisfyInterface(t *testing.T) {
+	limiters := map[string]Limiter{
+		"TokenBucket":          NewTokenBucket(10, 2),
+		"GCRA":                 NewGCRA(10, 2),
+		"SlidingWindowLog":     NewSlidingWindowLog(2, 100*time.Millisecond),
+		"SlidingWindowCounter": NewSlidingWindowCounter(2, 100*time.Millisecond),
+	}
+
+	for name, l := range limiters {
+		assert.True(t, l.Allow(), "%s should allow the first event", name)
+		assert.True(t, l.Allow(), "%s should allow the burst", name)
+		assert.False(t, l.Allow(), "%s should reject events past the burst", name)
+		assert.False(t, l.Reserve(3).OK, "%s should reject reservations larger than its capacity", name)
+	}
+}
+
+func TestWaitBlocksUntilAllowed(t *testing.T) {
+	l := NewTokenBucket(20, 1)
+	assert.True(t, l.Allow())
+
+	start := time.Now()
+	assert.NoError(t, l.Wait(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond, "Wait should block until a token is refilled")
+}
+
+func TestWaitContextCancelled(t *testing.T) {
+	l := NewTokenBucket(1, 1)
+	assert.True(t, l.Allow())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, l.Wait(ctx), context.DeadlineExceeded, "Wait should return when the context expires")
+	assert.InDelta(t, 0, l.Tokens(), 0.1, "A cancelled Wait should return its reserved token")
+
+	cancelled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	assert.ErrorIs(t, l.Wait(cancelled), context.Canceled, "Wait should fail fast on a cancelled context")
+}
+
+func TestWaitExceedsLimit(t *testing.T) {
+	l := NewTokenBucket(0, 1)
+	assert.True(t, l.Allow())
+	assert.ErrorIs(t, l.Wait(context.Background()), ErrExceedsLimit, "Wait should fail when no token can ever be available")
+}
@@ -0,0 +1,41 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func BenchmarkTokenBucketAllow(b *testing.B) {
+	l := NewTokenBucket(1e9, 1000)
+	for i := 0; i < b.N; i++ {
+		l.Allow()
+	}
+}
+
+func BenchmarkGCRAAllow(b *testing.B) {
+	l := NewGCRA(1e9, 1000)
+	for i := 0; i < b.N; i++ {
+		l.Allow()
+	}
+}
+
+func BenchmarkSlidingWindowLogAllow(b *testing.B) {
+	l := NewSlidingWindowLog(1000, time.Millisecond)
+	for i := 0; i < b.N; i++ {
+		l.Allow()
+	}
+}
+
+func BenchmarkSlidingWindowCounterAllow(b *testing.B) {
+	l := NewSlidingWindowCounter(1000, time.Millisecond)
+	for i := 0; i < b.N; i++ {
+		l.Allow()
+	}
+}
+
+func BenchmarkKeyedAllow(b *testing.B) {
+	k := NewKeyed[int](func() Limiter { return NewTokenBucket(1e9, 1000) }, time.Minute)
+	for i := 0; i < b.N; i++ {
+		k.Allow(i % 100)
+	}
+}
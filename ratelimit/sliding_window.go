@@ -0,0 +1,202 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// SlidingWindowLog is a Limiter that admits at most limit events in any window of the given length.
+// It keeps the timestamp of every admitted event, so it is exact but uses O(limit) memory.
+type SlidingWindowLog struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	log    []time.Time // Admission times of events in the current window, oldest first
+	now    func() time.Time
+}
+
+// NewSlidingWindowLog creates a SlidingWindowLog admitting limit events per window. A limit below 1 is treated as 1.
+// It panics if window is not positive.
+func NewSlidingWindowLog(limit int, window time.Duration) *SlidingWindowLog {
+	mustPositiveWindow(window)
+	limit = max(limit, 1)
+	return &SlidingWindowLog{
+		limit:  limit,
+		window: window,
+		log:    make([]time.Time, 0, limit),
+		now:    time.Now,
+	}
+}
+
+// Allow reports whether one event may happen now, consuming it if so.
+func (sl *SlidingWindowLog) Allow() bool {
+	return sl.reserve(1, false).OK
+}
+
+// Wait blocks until one event may happen or ctx is done.
+func (sl *SlidingWindowLog) Wait(ctx context.Context) error {
+	return wait(ctx, sl)
+}
+
+// Reserve reserves n events and returns how long to wait before they may happen.
+func (sl *SlidingWindowLog) Reserve(n int) Reservation {
+	return sl.reserve(n, true)
+}
+
+// reserve reserves n events. If mayDelay is false, events are only reserved when they may happen now.
+func (sl *SlidingWindowLog) reserve(n int, mayDelay bool) Reservation {
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+
+	if n > sl.limit {
+		return Reservation{}
+	}
+
+	now := sl.now()
+	sl.prune(now)
+	at := now
+	if excess := len(sl.log) + n - sl.limit; excess > 0 {
+		// The events fit once the excess oldest entries have left the window.
+		at = sl.log[excess-1].Add(sl.window)
+	}
+	// Delayed reservations are admitted in order, so never schedule before the latest one.
+	if last := len(sl.log) - 1; last >= 0 {
+		at = maxTime(at, sl.log[last])
+	}
+	if at.After(now) && !mayDelay {
+		return Reservation{}
+	}
+	for i := 0; i < n; i++ {
+		sl.log = append(sl.log, at)
+	}
+
+	return Reservation{OK: true, Delay: at.Sub(now), cancel: func() {
+		sl.mu.Lock()
+		defer sl.mu.Unlock()
+		for i, removed := len(sl.log)-1, 0; i >= 0 && removed < n; i-- {
+			if sl.log[i].Equal(at) {
+				sl.log = append(sl.log[:i], sl.log[i+1:]...)
+				removed++
+			}
+		}
+	}}
+}
+
+// prune drops entries that have left the window ending at now.
+func (sl *SlidingWindowLog) prune(now time.Time) {
+	cutoff := now.Add(-sl.window)
+	i := 0
+	for i < len(sl.log) && !sl.log[i].After(cutoff) {
+		i++
+	}
+	sl.log = append(sl.log[:0], sl.log[i:]...)
+}
+
+// SlidingWindowCounter is a Limiter that approximates a sliding window from the counts of the
+// current and previous fixed windows, weighting the previous count by its overlap with the sliding window.
+// It uses O(1) memory.
+type SlidingWindowCounter struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	start  time.Time // Start of the current fixed window
+	prev   int       // Events admitted in the previous fixed window
+	curr   int       // Events admitted in the current fixed window
+	last   time.Time // Latest admission time, which may lie in the future for delayed reservations
+	now    func() time.Time
+}
+
+// NewSlidingWindowCounter creates a SlidingWindowCounter admitting about limit events per window.
+// A limit below 1 is treated as 1. It panics if window is not positive.
+func NewSlidingWindowCounter(limit int, window time.Duration) *SlidingWindowCounter {
+	mustPositiveWindow(window)
+	return &SlidingWindowCounter{
+		limit:  max(limit, 1),
+		window: window,
+		now:    time.Now,
+	}
+}
+
+// Allow reports whether one event may happen now, consuming it if so.
+func (sc *SlidingWindowCounter) Allow() bool {
+	return sc.reserve(1, false).OK
+}
+
+// Wait blocks until one event may happen or ctx is done.
+func (sc *SlidingWindowCounter) Wait(ctx context.Context) error {
+	return wait(ctx, sc)
+}
+
+// Reserve reserves n events and returns how long to wait before they may happen.
+func (sc *SlidingWindowCounter) Reserve(n int) Reservation {
+	return sc.reserve(n, true)
+}
+
+// reserve reserves n events. If mayDelay is false, events are only reserved when they may happen now.
+func (sc *SlidingWindowCounter) reserve(n int, mayDelay bool) Reservation {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if n > sc.limit {
+		return Reservation{}
+	}
+
+	now := sc.now()
+	if sc.start.IsZero() {
+		sc.start = now
+	}
+	// Delayed reservations are admitted in order, so never schedule before the latest one.
+	at := maxTime(now, sc.last)
+	for {
+		sc.advance(at)
+		elapsed := at.Sub(sc.start)
+		weight := 1 - float64(elapsed)/float64(sc.window)
+		if float64(sc.prev)*weight+float64(sc.curr+n) <= float64(sc.limit) {
+			break
+		}
+		if sc.curr+n <= sc.limit && sc.prev > 0 {
+			// Wait until enough of the previous window has slid out.
+			need := 1 - float64(sc.limit-sc.curr-n)/float64(sc.prev)
+			at = sc.start.Add(time.Duration(need * float64(sc.window)))
+			break
+		}
+		at = sc.start.Add(sc.window)
+	}
+	if at.After(now) && !mayDelay {
+		return Reservation{}
+	}
+	sc.curr += n
+	sc.last = at
+	windowStart := sc.start
+
+	return Reservation{OK: true, Delay: at.Sub(now), cancel: func() {
+		sc.mu.Lock()
+		defer sc.mu.Unlock()
+		if sc.start.Equal(windowStart) {
+			sc.curr = max(sc.curr-n, 0)
+		}
+	}}
+}
+
+// advance rolls the fixed windows forward so that at falls in the current window.
+func (sc *SlidingWindowCounter) advance(at time.Time) {
+	elapsed := at.Sub(sc.start)
+	if elapsed < sc.window {
+		return
+	}
+	windows := elapsed / sc.window
+	if windows == 1 {
+		sc.prev, sc.curr = sc.curr, 0
+	} else {
+		sc.prev, sc.curr = 0, 0
+	}
+	sc.start = sc.start.Add(windows * sc.window)
+}
+
+// mustPositiveWindow panics if window is not positive, since no sliding window can be built from it.
+func mustPositiveWindow(window time.Duration) {
+	if window <= 0 {
+		panic("ratelimit: window must be positive")
+	}
+}
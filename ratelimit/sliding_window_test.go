@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlidingWindowLogAllow(t *testing.T) {
+	clock := newFakeClock()
+	sl := NewSlidingWindowLog(3, time.Second)
+	sl.now = clock.Now
+
+	assert.True(t, sl.Allow())
+	clock.Advance(400 * time.Millisecond)
+	assert.True(t, sl.Allow())
+	assert.True(t, sl.Allow())
+	assert.False(t, sl.Allow(), "Log should reject once the window is full")
+
+	clock.Advance(600 * time.Millisecond)
+	assert.True(t, sl.Allow(), "Oldest event should have left the window")
+	assert.False(t, sl.Allow(), "Only one slot should have been freed")
+}
+
+func TestSlidingWindowLogReserve(t *testing.T) {
+	clock := newFakeClock()
+	sl := NewSlidingWindowLog(2, time.Second)
+	sl.now = clock.Now
+
+	assert.True(t, sl.Allow())
+	clock.Advance(300 * time.Millisecond)
+	assert.True(t, sl.Allow())
+
+	r := sl.Reserve(1)
+	assert.True(t, r.OK)
+	assert.Equal(t, 700*time.Millisecond, r.Delay, "Reservation should wait for the oldest event to expire")
+
+	r.Cancel()
+	clock.Advance(700 * time.Millisecond)
+	assert.True(t, sl.Allow(), "Cancelled reservation should free its slot")
+}
+
+func TestSlidingWindowCounterWeighting(t *testing.T) {
+	clock := newFakeClock()
+	sc := NewSlidingWindowCounter(4, time.Second)
+	sc.now = clock.Now
+
+	for i := 0; i < 4; i++ {
+		assert.True(t, sc.Allow())
+	}
+	assert.False(t, sc.Allow(), "Counter should reject once the window is full")
+
+	// Half way into the next window the previous window still counts for 2 events.
+	clock.Advance(1500 * time.Millisecond)
+	assert.True(t, sc.Allow())
+	assert.True(t, sc.Allow())
+	assert.False(t, sc.Allow(), "Weighted previous window should still limit events")
+}
+
+func TestSlidingWindowCounterReserve(t *testing.T) {
+	clock := newFakeClock()
+	sc := NewSlidingWindowCounter(2, time.Second)
+	sc.now = clock.Now
+
+	assert.True(t, sc.Reserve(2).OK)
+	r := sc.Reserve(1)
+	assert.True(t, r.OK)
+	assert.Equal(t, 1500*time.Millisecond, r.Delay, "Reservation should wait until the weighted count leaves room")
+	assert.False(t, sc.Allow(), "Allow should not overtake a pending reservation")
+}
+
+func TestSlidingWindowRejectsNonPositiveWindow(t *testing.T) {
+	assert.Panics(t, func() { NewSlidingWindowCounter(1, 0) }, "A zero window should be rejected")
+	assert.Panics(t, func() { NewSlidingWindowLog(1, -time.Second) }, "A negative window should be rejected")
+}
@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// TokenBucket is a Limiter that refills tokens at a fixed rate up to a maximum burst.
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // Tokens added per second
+	burst  int     // Maximum number of tokens in the bucket
+	tokens float64 // Available tokens; negative when future tokens are reserved
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewTokenBucket creates a TokenBucket that adds rate tokens per second and holds at most burst tokens.
+// The bucket starts full. A burst below 1 is treated as 1.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	burst = max(burst, 1)
+	return &TokenBucket{
+		rate:   rate,
+		burst:  burst,
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// Allow reports whether one token is available now, consuming it if so.
+func (tb *TokenBucket) Allow() bool {
+	return tb.reserve(1, false).OK
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (tb *TokenBucket) Wait(ctx context.Context) error {
+	return wait(ctx, tb)
+}
+
+// Reserve takes n tokens, borrowing from the future if necessary, and returns how long to wait before using them.
+func (tb *TokenBucket) Reserve(n int) Reservation {
+	return tb.reserve(n, true)
+}
+
+// reserve takes n tokens. If mayDelay is false, tokens are only taken when they are available now.
+func (tb *TokenBucket) reserve(n int, mayDelay bool) Reservation {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	if n > tb.burst || (tb.rate <= 0 && float64(n) > tb.tokens) {
+		return Reservation{}
+	}
+
+	now := tb.now()
+	tb.refill(now)
+	var delay time.Duration
+	if missing := float64(n) - tb.tokens; missing > 0 {
+		if !mayDelay {
+			return Reservation{}
+		}
+		delay = time.Duration(missing / tb.rate * float64(time.Second))
+	}
+	tb.tokens -= float64(n)
+
+	return Reservation{OK: true, Delay: delay, cancel: func() {
+		tb.mu.Lock()
+		defer tb.mu.Unlock()
+		tb.refill(tb.now())
+		tb.tokens = min(tb.tokens+float64(n), float64(tb.burst))
+	}}
+}
+
+// refill adds the tokens accumulated since the last update.
+func (tb *TokenBucket) refill(now time.Time) {
+	if !tb.last.IsZero() && now.After(tb.last) {
+		elapsed := now.Sub(tb.last).Seconds()
+		tb.tokens = min(tb.tokens+elapsed*tb.rate, float64(tb.burst))
+	}
+	tb.last = maxTime(now, tb.last)
+}
+
+// Tokens returns the number of tokens currently available.
+func (tb *TokenBucket) Tokens() float64 {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+	tb.refill(tb.now())
+	return tb.tokens
+}
@@ -0,0 +1,43 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucketRefill(t *testing.T) {
+	clock := newFakeClock()
+	tb := NewTokenBucket(10, 5)
+	tb.now = clock.Now
+
+	for i := 0; i < 5; i++ {
+		assert.True(t, tb.Allow(), "Full bucket should allow the whole burst")
+	}
+	assert.False(t, tb.Allow(), "Empty bucket should reject")
+
+	clock.Advance(100 * time.Millisecond)
+	assert.True(t, tb.Allow(), "One token should be refilled after 100ms at 10/s")
+	assert.False(t, tb.Allow(), "Only one token should have been refilled")
+
+	clock.Advance(10 * time.Second)
+	assert.InDelta(t, 5, tb.Tokens(), 1e-9, "Refill should be capped at the burst")
+}
+
+func TestTokenBucketReserve(t *testing.T) {
+	clock := newFakeClock()
+	tb := NewTokenBucket(10, 5)
+	tb.now = clock.Now
+
+	r := tb.Reserve(5)
+	assert.True(t, r.OK)
+	assert.Equal(t, time.Duration(0), r.Delay, "Tokens available now should not be delayed")
+
+	r = tb.Reserve(2)
+	assert.True(t, r.OK)
+	assert.Equal(t, 200*time.Millisecond, r.Delay, "Borrowed tokens should be delayed by their refill time")
+
+	r.Cancel()
+	assert.InDelta(t, 0, tb.Tokens(), 1e-9, "Cancel should return the borrowed tokens")
+}
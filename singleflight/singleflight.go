@@ -0,0 +1,119 @@
+package singleflight
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// PanicError is returned to every waiter when the shared function panics.
+type PanicError struct {
+	Value any    // Value passed to panic
+	Stack []byte // Stack trace of the panicking goroutine
+}
+
+// Error implements the error interface.
+func (p *PanicError) Error() string {
+	return fmt.Sprintf("singleflight: function panicked: %v\n\n%s", p.Value, p.Stack)
+}
+
+// call is an in-flight or recently completed execution for one key.
+type call[V any] struct {
+	done      chan struct{} // Closed once val and err are set
+	val       V
+	err       error
+	expiresAt time.Time // When a completed result stops being reused
+}
+
+// Group merges concurrent calls with the same key into a single execution whose result is shared by every caller.
+type Group[K comparable, V any] struct {
+	mu        sync.Mutex
+	calls     map[K]*call[V]
+	resultTTL time.Duration // How long a completed result keeps being returned to new callers
+	now       func() time.Time
+}
+
+// NewGroup creates a Group. A positive resultTTL keeps each result for that long after completion,
+// so callers arriving shortly after the execution finished reuse it instead of starting a new one.
+func NewGroup[K comparable, V any](resultTTL time.Duration) *Group[K, V] {
+	return &Group[K, V]{
+		calls:     make(map[K]*call[V]),
+		resultTTL: resultTTL,
+		now:       time.Now,
+	}
+}
+
+// Do executes fn for key unless an execution for key is already in flight (or cached), in which case it waits
+// for that execution and returns its result. If ctx is done first, Do returns ctx.Err() but the shared execution
+// keeps running for the other waiters.
+func (g *Group[K, V]) Do(ctx context.Context, key K, fn func() (V, error)) (V, error) {
+	c := g.join(key, fn)
+	select {
+	case <-c.done:
+		return c.val, c.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// Forget makes the next call for key start a new execution instead of joining the current one or reusing a cached result.
+func (g *Group[K, V]) Forget(key K) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.calls, key)
+}
+
+// join returns the call for key, starting a new execution if there is none to share.
+func (g *Group[K, V]) join(key K, fn func() (V, error)) *call[V] {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if c, ok := g.calls[key]; ok {
+		select {
+		case <-c.done:
+			if g.now().Before(c.expiresAt) {
+				return c
+			}
+		default:
+			return c
+		}
+	}
+
+	c := &call[V]{done: make(chan struct{})}
+	g.calls[key] = c
+	go g.run(key, c, fn)
+	return c
+}
+
+// run executes fn, publishes its result to the waiters and schedules the removal of the call.
+func (g *Group[K, V]) run(key K, c *call[V], fn func() (V, error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+
+		g.mu.Lock()
+		c.expiresAt = g.now().Add(g.resultTTL)
+		close(c.done)
+		g.mu.Unlock()
+
+		if g.resultTTL > 0 {
+			time.AfterFunc(g.resultTTL, func() { g.remove(key, c) })
+		} else {
+			g.remove(key, c)
+		}
+	}()
+	c.val, c.err = fn()
+}
+
+// remove deletes c from the group unless it has already been replaced.
+func (g *Group[K, V]) remove(key K, c *call[V]) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.calls[key] == c {
+		delete(g.calls, key)
+	}
+}
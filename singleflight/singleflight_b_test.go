@@ -0,0 +1,29 @@
+package singleflight
+
+import (
+	"context"
+	"testing"
+)
+
+func BenchmarkGroupDo(b *testing.B) {
+	g := NewGroup[int, int](0)
+	ctx := context.Background()
+	fn := func() (int, error) { return 1, nil }
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = g.Do(ctx, i%100, fn)
+	}
+}
+
+func BenchmarkGroupDoParallel(b *testing.B) {
+	g := NewGroup[int, int](0)
+	ctx := context.Background()
+	fn := func() (int, error) { return 1, nil }
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, _ = g.Do(ctx, 0, fn)
+		}
+	})
+}
@@ -0,0 +1,142 @@
+package singleflight
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupDoSingleCall(t *testing.T) {
+	g := NewGroup[string, int](0)
+	v, err := g.Do(context.Background(), "key", func() (int, error) { return 42, nil })
+
+	assert.NoError(t, err)
+	assert.Equal(t, 42, v, "Do should return the function result")
+}
+
+func TestGroupDoCoalesces(t *testing.T) {
+	g := NewGroup[string, int](0)
+	var calls int32
+	release := make(chan struct{})
+
+	var wg sync.WaitGroup
+	results := make([]int, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = g.Do(context.Background(), "key", func() (int, error) {
+				atomic.AddInt32(&calls, 1)
+				<-release
+				return 7, nil
+			})
+		}(i)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls, "Concurrent calls with the same key should execute once")
+	for _, r := range results {
+		assert.Equal(t, 7, r, "Every waiter should receive the shared result")
+	}
+}
+
+func TestGroupDoSharesError(t *testing.T) {
+	g := NewGroup[int, string](0)
+	errBoom := errors.New("boom")
+	release := make(chan struct{})
+
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := g.Do(context.Background(), 1, func() (string, error) {
+				<-release
+				return "", errBoom
+			})
+			errs <- err
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	assert.ErrorIs(t, <-errs, errBoom)
+	assert.ErrorIs(t, <-errs, errBoom, "Every waiter should receive the shared error")
+}
+
+func TestGroupDoWaiterContext(t *testing.T) {
+	g := NewGroup[string, int](0)
+	release := make(chan struct{})
+	fn := func() (int, error) {
+		<-release
+		return 1, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := g.Do(ctx, "key", fn)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Waiter should abandon the wait when its context expires")
+
+	done := make(chan int)
+	go func() {
+		v, _ := g.Do(context.Background(), "key", func() (int, error) { return 2, nil })
+		done <- v
+	}()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	assert.Equal(t, 1, <-done, "Abandoned wait should not cancel the shared call")
+}
+
+func TestGroupForget(t *testing.T) {
+	g := NewGroup[string, int](0)
+	release := make(chan struct{})
+	go func() {
+		_, _ = g.Do(context.Background(), "key", func() (int, error) {
+			<-release
+			return 1, nil
+		})
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	g.Forget("key")
+	v, err := g.Do(context.Background(), "key", func() (int, error) { return 2, nil })
+	close(release)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, v, "Forget should make the next call start a new execution")
+}
+
+func TestGroupResultTTL(t *testing.T) {
+	g := NewGroup[string, int](50 * time.Millisecond)
+	var calls int32
+	fn := func() (int, error) {
+		return int(atomic.AddInt32(&calls, 1)), nil
+	}
+
+	v1, _ := g.Do(context.Background(), "key", fn)
+	v2, _ := g.Do(context.Background(), "key", fn)
+	assert.Equal(t, v1, v2, "Result should be reused within the TTL")
+
+	time.Sleep(70 * time.Millisecond)
+	v3, _ := g.Do(context.Background(), "key", fn)
+	assert.Equal(t, 2, v3, "Result should expire after the TTL")
+
+	g.Forget("key")
+	v4, _ := g.Do(context.Background(), "key", fn)
+	assert.Equal(t, 3, v4, "Forget should drop a cached result")
+}
+
+func TestGroupDoPanic(t *testing.T) {
+	g := NewGroup[string, int](0)
+	_, err := g.Do(context.Background(), "key", func() (int, error) { panic("boom") })
+
+	var panicErr *PanicError
+	assert.ErrorAs(t, err, &panicErr, "A panic should be returned as a PanicError")
+	assert.Equal(t, "boom", panicErr.Value)
+}